@@ -1,12 +1,133 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/terraform-providers/terraform-provider-rabbitmq/rabbitmq"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		var command func([]string) error
+		switch os.Args[1] {
+		case "generate":
+			command = generate
+		case "convert":
+			command = convert
+		}
+
+		if command != nil {
+			if err := command(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: rabbitmq.Provider,
 	})
 }
+
+// generate writes the configuration of the objects of a running cluster.
+// The connection settings default to the RABBITMQ_* environment variables,
+// like the provider ones.
+func generate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s generate [options]\n\n", os.Args[0])
+		fmt.Fprintf(flags.Output(), "Writes the resources and import blocks of the objects of a RabbitMQ cluster.\n\n")
+		flags.PrintDefaults()
+	}
+
+	config := map[string]interface{}{}
+	for _, argument := range []string{"endpoint", "username", "password", "cacert_file", "clientcert_file", "clientkey_file", "proxy"} {
+		argument := argument
+		flags.Func(argument, "the provider "+argument+" argument", func(value string) error {
+			config[argument] = value
+			return nil
+		})
+	}
+	insecure := flags.Bool("insecure", false, "the provider insecure argument")
+	vhost := flags.String("vhost", "", "only generate the objects of this vhost")
+	output := flags.String("output", "", "the file to write to, instead of the standard output")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == "insecure" {
+			config["insecure"] = *insecure
+		}
+	})
+
+	rmqc, err := rabbitmq.NewClient(config)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	return rabbitmq.Generate(rmqc, *vhost, w)
+}
+
+// convert writes the configuration of the objects of a definitions export
+// file, and reports the ones which cannot be converted.
+func convert(args []string) error {
+	flags := flag.NewFlagSet("convert", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s convert [options] <definitions.json>\n\n", os.Args[0])
+		fmt.Fprintf(flags.Output(), "Writes the resources of the objects of a RabbitMQ definitions export file.\n\n")
+		flags.PrintDefaults()
+	}
+
+	vhost := flags.String("vhost", "/", "the vhost of the objects of a vhost export")
+	output := flags.String("output", "", "the file to write to, instead of the standard output")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	document, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	unconverted, err := rabbitmq.Convert(document, *vhost, w)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range unconverted {
+		fmt.Fprintf(os.Stderr, "Warning: %s cannot be converted\n", id)
+	}
+
+	return nil
+}
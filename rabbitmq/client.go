@@ -0,0 +1,95 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+)
+
+// providerMeta is the meta value of the provider. rabbit-hole doesn't cover
+// every field and endpoint of the management API and doesn't expose the
+// transport of its client, it is kept here so that the raw requests below go
+// through the same TLS/proxy settings as the rest of the provider.
+type providerMeta struct {
+	*rabbithole.Client
+
+	transport http.RoundTripper
+
+	// The provider on_conflict setting, see onConflict
+	onConflict string
+}
+
+func newProviderMeta(endpoint string, username string, password string, transport http.RoundTripper) (*providerMeta, error) {
+	rmqc, err := rabbithole.NewTLSClient(endpoint, username, password, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	return &providerMeta{
+		Client:    rmqc,
+		transport: transport,
+	}, nil
+}
+
+// withCredentials returns a copy of the meta value authenticating as another
+// user with the same endpoint and settings.
+func (m *providerMeta) withCredentials(username string, password string) *providerMeta {
+	client := *m.Client
+	client.Username = username
+	client.Password = password
+
+	copy := *m
+	copy.Client = &client
+	return &copy
+}
+
+// apiRequest sends a request to the management API and decodes the JSON
+// response into rec, if any. Errors are reported as rabbithole.ErrorResponse
+// so that checkDeleted can be used with them.
+func apiRequest(m *providerMeta, method string, path string, body interface{}, rec interface{}) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, m.Endpoint+"/api/"+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Close = true
+	req.SetBasicAuth(m.Username, m.Password)
+	req.Header.Add("Content-Type", "application/json")
+
+	httpc := &http.Client{Transport: m.transport}
+
+	resp, err := httpc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		errorResponse := rabbithole.ErrorResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
+			errorResponse.Message = fmt.Sprintf("Error %d from RabbitMQ", resp.StatusCode)
+		}
+		errorResponse.StatusCode = resp.StatusCode
+		return resp, errorResponse
+	}
+
+	if rec != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(rec); err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
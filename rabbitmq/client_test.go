@@ -0,0 +1,52 @@
+package rabbitmq
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+)
+
+func TestApiRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "guest" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.EscapedPath() {
+		case "/api/queues/%2F/foo":
+			w.Write([]byte(`{"name": "foo", "vhost": "/", "operator_policy": "limits"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Object Not Found", "reason": "Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	rmqc, err := newProviderMeta(server.URL, "guest", "secret", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queue, err := getQueueDetails(rmqc, "/", "foo")
+	if err != nil {
+		t.Fatalf("apiRequest failed: %v", err)
+	}
+	if queue.Name != "foo" || queue.OperatorPolicy != "limits" {
+		t.Errorf("apiRequest decoded an unexpected queue: %#v", queue)
+	}
+
+	_, err = getQueueDetails(rmqc, "/", "bar")
+	var errorResponse rabbithole.ErrorResponse
+	if !errors.As(err, &errorResponse) || errorResponse.StatusCode != 404 || errorResponse.Reason != "Not Found" {
+		t.Errorf("apiRequest should return an ErrorResponse, got: %v", err)
+	}
+
+	_, err = getQueueDetails(rmqc.withCredentials("guest", "wrong"), "/", "foo")
+	if !errors.As(err, &errorResponse) || errorResponse.StatusCode != 401 {
+		t.Errorf("apiRequest should use the given credentials, got: %v", err)
+	}
+}
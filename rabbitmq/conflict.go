@@ -0,0 +1,68 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// on_conflict sets what creating a resource does when its object already
+// exists: fail, adopt the object as it is, or overwrite it with the
+// configuration. Resources use the provider setting unless they have their
+// own, and fall back to a default of their own.
+var onConflictValues = []string{"error", "adopt", "overwrite"}
+
+func onConflictSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(onConflictValues, false),
+	}
+}
+
+// onConflict returns the on_conflict setting of a resource, which can be a
+// schema.ResourceData or a schema.ResourceDiff.
+func onConflict(d interface{ Get(string) interface{} }, m *providerMeta, defaultValue string) string {
+	if v, _ := d.Get("on_conflict").(string); v != "" {
+		return v
+	}
+
+	if m.onConflict != "" {
+		return m.onConflict
+	}
+
+	return defaultValue
+}
+
+// checkConflict looks up the object of a resource being created with get. It
+// returns "create" when the object doesn't exist, and otherwise "adopt" or
+// "overwrite" according to on_conflict, or an error.
+func checkConflict(d *schema.ResourceData, m *providerMeta, defaultValue string, description string, get func() error) (string, error) {
+	err := get()
+
+	var errorResponse rabbithole.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 {
+		return "create", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error checking for an existing %s: %s", description, err)
+	}
+
+	action := onConflict(d, m, defaultValue)
+	if action == "error" {
+		return "", conflictError(description)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %s already exists, on_conflict is %s", description, action)
+
+	return action, nil
+}
+
+func conflictError(description string) error {
+	return fmt.Errorf("%s already exists, import it or set on_conflict to adopt or overwrite", description)
+}
@@ -0,0 +1,34 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestOnConflict(t *testing.T) {
+	cases := []struct {
+		resource string
+		provider string
+		expected string
+	}{
+		{"", "", "error"},
+		{"", "overwrite", "overwrite"},
+		{"adopt", "", "adopt"},
+		{"adopt", "overwrite", "adopt"},
+	}
+
+	for _, c := range cases {
+		m := &providerMeta{onConflict: c.provider}
+
+		raw := map[string]interface{}{"name": "test"}
+		if c.resource != "" {
+			raw["on_conflict"] = c.resource
+		}
+		d := schema.TestResourceDataRaw(t, resourceVhost().Schema, raw)
+
+		if actual := onConflict(d, m, "error"); actual != c.expected {
+			t.Errorf("Expected %s with on_conflict %q and provider on_conflict %q, got %s", c.expected, c.resource, c.provider, actual)
+		}
+	}
+}
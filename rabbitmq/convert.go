@@ -0,0 +1,199 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+)
+
+// definitionsMetadata lists the fields of the definitions which don't hold
+// objects.
+var definitionsMetadata = map[string]bool{
+	"rabbit_version":   true,
+	"rabbitmq_version": true,
+	"product_name":     true,
+	"product_version":  true,
+}
+
+// definitionsConversionOrder lists the sections of the definitions in the
+// order of Generate, so that the resources reference the ones they depend on.
+var definitionsConversionOrder = []string{
+	"vhosts",
+	"users",
+	"permissions",
+	"exchanges",
+	"topic_permissions",
+	"queues",
+	"bindings",
+	"policies",
+	"operator_policies",
+	"parameters",
+	"global_parameters",
+}
+
+// Convert writes the resources of this provider matching the objects of a
+// definitions export, without import blocks. The objects of a vhost export,
+// which don't have a vhost field, are converted in the given vhost. The ids of
+// the objects which cannot be converted are returned.
+func Convert(document []byte, vhost string, w io.Writer) ([]string, error) {
+	defs, err := parseDefinitions(string(document))
+	if err != nil {
+		return nil, err
+	}
+
+	g := newHCLGenerator(false)
+	unconverted := []string{}
+
+	for _, name := range definitionsConversionOrder {
+		section := 0
+		for definitionSections[section].name != name {
+			section++
+		}
+
+		for _, object := range defs.objects(name) {
+			converted, err := g.definition(name, object, vhost)
+			if err != nil {
+				return nil, err
+			}
+			if !converted {
+				id, err := definitionObjectId(section, object, vhost)
+				if err != nil {
+					return nil, err
+				}
+				unconverted = append(unconverted, id)
+			}
+		}
+	}
+
+	// The sections unknown to this provider are reported as a whole
+	var unknown []string
+	for name := range defs {
+		known := definitionsMetadata[name]
+		for _, section := range definitionSections {
+			known = known || section.name == name
+		}
+		if !known {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		unconverted = append(unconverted, fmt.Sprintf("section:%s", name))
+	}
+
+	if _, err := w.Write(g.bytes()); err != nil {
+		return nil, err
+	}
+
+	return unconverted, nil
+}
+
+// definition appends the resource of an object of a section of the
+// definitions, and returns false if there is no resource for it.
+func (g *hclGenerator) definition(section string, object map[string]interface{}, vhost string) (bool, error) {
+	switch section {
+	case "vhosts":
+		g.vhost(definitionString(object, "name"))
+	case "users":
+		var user rabbithole.UserInfo
+		if err := decodeDefinition(object, vhost, &user); err != nil {
+			return false, err
+		}
+		g.user(user)
+	case "permissions":
+		var permission rabbithole.PermissionInfo
+		if err := decodeDefinition(object, vhost, &permission); err != nil {
+			return false, err
+		}
+		g.permissions(permission)
+	case "topic_permissions":
+		var permission rabbithole.TopicPermissionInfo
+		if err := decodeDefinition(object, vhost, &permission); err != nil {
+			return false, err
+		}
+		g.topicPermission(permission)
+	case "exchanges":
+		var exchange rabbithole.ExchangeInfo
+		if err := decodeDefinition(object, vhost, &exchange); err != nil {
+			return false, err
+		}
+		return g.exchange(exchange), nil
+	case "queues":
+		var queue rabbithole.QueueInfo
+		if err := decodeDefinition(object, vhost, &queue); err != nil {
+			return false, err
+		}
+		return g.queue(queue), nil
+	case "bindings":
+		var binding rabbithole.BindingInfo
+		if err := decodeDefinition(object, vhost, &binding); err != nil {
+			return false, err
+		}
+		return g.binding(binding), nil
+	case "policies":
+		var policy rabbithole.Policy
+		if err := decodeDefinition(object, vhost, &policy); err != nil {
+			return false, err
+		}
+		return g.policy(policy), nil
+	case "operator_policies":
+		var policy rabbithole.OperatorPolicy
+		if err := decodeDefinition(object, vhost, &policy); err != nil {
+			return false, err
+		}
+		g.operatorPolicy(policy)
+	case "parameters":
+		switch definitionString(object, "component") {
+		case "shovel":
+			var shovel rabbithole.ShovelInfo
+			if err := decodeDefinition(object, vhost, &shovel); err != nil {
+				return false, err
+			}
+			g.shovel(shovel)
+		case rabbithole.FederationUpstreamComponent:
+			var upstream rabbithole.FederationUpstream
+			if err := decodeDefinition(object, vhost, &upstream); err != nil {
+				return false, err
+			}
+			g.federationUpstream(upstream)
+		case "vhost-limits":
+			var limits rabbithole.VhostLimitsInfo
+			if err := decodeDefinition(object, vhost, &limits); err != nil {
+				return false, err
+			}
+			return g.vhostLimits(limits), nil
+		default:
+			return false, nil
+		}
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// decodeDefinition decodes an object of the definitions into the matching
+// rabbithole type, in the given vhost when the object has none.
+func decodeDefinition(object map[string]interface{}, vhost string, v interface{}) error {
+	if _, ok := object["vhost"]; !ok {
+		copied := map[string]interface{}{"vhost": vhost}
+		for key, value := range object {
+			copied[key] = value
+		}
+		object = copied
+	}
+
+	bytes, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(bytes, v); err != nil {
+		return fmt.Errorf("Unable to convert definitions object %v: %s", object, err)
+	}
+
+	return nil
+}
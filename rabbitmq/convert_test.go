@@ -0,0 +1,74 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	document, err := os.ReadFile("testdata/definitions.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile("testdata/definitions.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hcl bytes.Buffer
+	unconverted, err := Convert(document, "/", &hcl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hcl.String() != string(expected) {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, hcl.String())
+	}
+
+	// The objects declared by RabbitMQ are left out, and reported
+	expectedUnconverted := []string{
+		"exchange:orders/amq.direct",
+		"queue:orders/amq.gen-1",
+		"binding:orders//queue/orders/orders/{}",
+		"global_parameter:cluster_name",
+	}
+	if !reflect.DeepEqual(unconverted, expectedUnconverted) {
+		t.Errorf("Expected unconverted objects %v, got %v", expectedUnconverted, unconverted)
+	}
+}
+
+func TestConvertVhostDefinitions(t *testing.T) {
+	document, err := os.ReadFile("testdata/vhost_definitions.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hcl bytes.Buffer
+	unconverted, err := Convert(document, "orders", &hcl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{`vhost = "orders"`, `resource "rabbitmq_operator_policy" "orders_limit" {`, `max-length = "1000"`} {
+		if !strings.Contains(hcl.String(), expected) {
+			t.Errorf("Expected %q in:\n%s", expected, hcl.String())
+		}
+	}
+
+	if len(unconverted) > 0 {
+		t.Errorf("Expected no unconverted objects, got %v", unconverted)
+	}
+}
+
+func TestConvertInvalidDefinitions(t *testing.T) {
+	var hcl bytes.Buffer
+	if _, err := Convert([]byte(`{"queues": [`), "/", &hcl); err == nil {
+		t.Error("Expected an error for invalid definitions")
+	}
+	if _, err := Convert([]byte(`{"queues": [{"name": "orders", "durable": "yes"}]}`), "/", &hcl); err == nil {
+		t.Error("Expected an error for an invalid queue")
+	}
+}
@@ -0,0 +1,165 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcesBindings() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadBindings,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+			},
+			"source_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"destination_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"destination_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"queue", "exchange"}, false),
+			},
+			"routing_key_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"arguments": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"bindings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"destination": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"destination_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"routing_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"properties_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"arguments_json": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadBindings(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	vhost := d.Get("vhost").(string)
+	destinationType := d.Get("destination_type").(string)
+	arguments := d.Get("arguments").(map[string]interface{})
+
+	regexps := map[string]*regexp.Regexp{}
+	for _, key := range []string{"source_regex", "destination_regex", "routing_key_regex"} {
+		if v, ok := d.GetOk(key); ok {
+			regexps[key] = regexp.MustCompile(v.(string))
+		}
+	}
+
+	bindingInfos, err := rmqc.ListBindingsIn(vhost)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %d bindings retrieved in %s", len(bindingInfos), vhost)
+
+	bindings := []map[string]interface{}{}
+	for _, binding := range bindingInfos {
+		// The default exchange has an implicit binding to every queue
+		if binding.Source == "" {
+			continue
+		}
+		if re, ok := regexps["source_regex"]; ok && !re.MatchString(binding.Source) {
+			continue
+		}
+		if re, ok := regexps["destination_regex"]; ok && !re.MatchString(binding.Destination) {
+			continue
+		}
+		if re, ok := regexps["routing_key_regex"]; ok && !re.MatchString(binding.RoutingKey) {
+			continue
+		}
+		if destinationType != "" && binding.DestinationType != destinationType {
+			continue
+		}
+		if !argumentsMatch(binding.Arguments, arguments) {
+			continue
+		}
+
+		bytes, err := json.Marshal(binding.Arguments)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		bindings = append(bindings, map[string]interface{}{
+			"id":               bindingId(binding.Vhost, binding),
+			"vhost":            binding.Vhost,
+			"source":           binding.Source,
+			"destination":      binding.Destination,
+			"destination_type": binding.DestinationType,
+			"routing_key":      binding.RoutingKey,
+			"properties_key":   binding.PropertiesKey,
+			"arguments_json":   string(bytes),
+		})
+	}
+
+	if err := d.Set("bindings", bindings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(vhost)
+
+	return diags
+}
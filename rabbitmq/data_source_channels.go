@@ -0,0 +1,163 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesChannels() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadChannels,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Only the channels with a consumer on this queue are listed
+			"queue": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"channels": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connection": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"peer_host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"peer_port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"prefetch_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"messages_unacknowledged": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"consumer_tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadChannels(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	vhost := d.Get("vhost").(string)
+	user := d.Get("user").(string)
+	queue := d.Get("queue").(string)
+
+	consumers, err := listConsumers(rmqc, vhost, queue)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	consumerTags := make(map[string][]string)
+	for _, consumer := range consumers {
+		channel := consumer.ChannelDetails.Name
+		consumerTags[channel] = append(consumerTags[channel], consumer.ConsumerTag)
+	}
+
+	channelInfos, err := rmqc.ListChannels()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %d channels retrieved", len(channelInfos))
+
+	names := []string{}
+	channels := []map[string]interface{}{}
+	for _, channel := range channelInfos {
+		if vhost != "" && channel.Vhost != vhost {
+			continue
+		}
+		if user != "" && channel.User != user {
+			continue
+		}
+		tags, consuming := consumerTags[channel.Name]
+		if queue != "" && !consuming {
+			continue
+		}
+		if tags == nil {
+			tags = []string{}
+		}
+
+		names = append(names, channel.Name)
+		channels = append(channels, map[string]interface{}{
+			"name":                    channel.Name,
+			"number":                  channel.Number,
+			"vhost":                   channel.Vhost,
+			"user":                    channel.User,
+			"node":                    channel.Node,
+			"connection":              channel.ConnectionDetails.Name,
+			"peer_host":               channel.ConnectionDetails.PeerHost,
+			"peer_port":               int(channel.ConnectionDetails.PeerPort),
+			"prefetch_count":          channel.PrefetchCount,
+			"messages_unacknowledged": channel.UnacknowledgedMessageCount,
+			"consumer_tags":           tags,
+		})
+	}
+
+	d.Set("names", names)
+	if err := d.Set("channels", channels); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s/%s", user, vhost, queue))
+
+	return diags
+}
@@ -0,0 +1,190 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesConnections() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadConnections,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Only the connections with a consumer on this queue are listed
+			"queue": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"connections": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"peer_host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"peer_port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"tls": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"channels": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"client_properties": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadConnections(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	vhost := d.Get("vhost").(string)
+	user := d.Get("user").(string)
+	queue := d.Get("queue").(string)
+
+	var consuming map[string]bool
+	if queue != "" {
+		consumers, err := listConsumers(rmqc, vhost, queue)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		consuming = make(map[string]bool)
+		for _, consumer := range consumers {
+			consuming[consumer.ChannelDetails.ConnectionName] = true
+		}
+	}
+
+	connectionInfos, err := rmqc.ListConnections()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %d connections retrieved", len(connectionInfos))
+
+	names := []string{}
+	connections := []map[string]interface{}{}
+	for _, connection := range connectionInfos {
+		if vhost != "" && connection.Vhost != vhost {
+			continue
+		}
+		if user != "" && connection.User != user {
+			continue
+		}
+		if consuming != nil && !consuming[connection.Name] {
+			continue
+		}
+
+		clientProperties, err := flattenClientProperties(connection.ClientProperties)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		names = append(names, connection.Name)
+		connections = append(connections, map[string]interface{}{
+			"name":              connection.Name,
+			"vhost":             connection.Vhost,
+			"user":              connection.User,
+			"node":              connection.Node,
+			"state":             connection.State,
+			"protocol":          connection.Protocol,
+			"peer_host":         connection.PeerHost,
+			"peer_port":         int(connection.PeerPort),
+			"tls":               connection.UsesTLS,
+			"channels":          connection.Channels,
+			"client_properties": clientProperties,
+		})
+	}
+
+	d.Set("names", names)
+	if err := d.Set("connections", connections); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s/%s", user, vhost, queue))
+
+	return diags
+}
+
+// flattenClientProperties converts the client properties to strings, the
+// nested ones such as capabilities are encoded as JSON.
+func flattenClientProperties(properties rabbithole.Properties) (map[string]interface{}, error) {
+	flattened := make(map[string]interface{})
+	for key, value := range properties {
+		switch v := value.(type) {
+		case string:
+			flattened[key] = v
+		case map[string]interface{}, []interface{}:
+			bytes, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			flattened[key] = string(bytes)
+		default:
+			flattened[key] = fmt.Sprint(v)
+		}
+	}
+
+	return flattened, nil
+}
@@ -0,0 +1,52 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceConnections(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceConnectionsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_connections.test", "names.#", "0"),
+					resource.TestCheckResourceAttr("data.rabbitmq_channels.test", "names.#", "0"),
+					resource.TestCheckResourceAttr("data.rabbitmq_consumers.test", "consumer_tags.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Nothing connects to a freshly created vhost
+const testAccDataSourceConnectionsConfig = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    vhost = rabbitmq_vhost.test.name
+    settings {
+        durable = true
+    }
+}
+
+data "rabbitmq_connections" "test" {
+    vhost = rabbitmq_vhost.test.name
+}
+
+data "rabbitmq_channels" "test" {
+    vhost = rabbitmq_vhost.test.name
+    user = "guest"
+}
+
+data "rabbitmq_consumers" "test" {
+    vhost = rabbitmq_queue.test.vhost
+    queue = rabbitmq_queue.test.name
+}`
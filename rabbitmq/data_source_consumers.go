@@ -0,0 +1,186 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesConsumers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadConsumers,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"queue": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"consumer_tags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"consumers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"consumer_tag": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"queue": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"channel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connection": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"peer_host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"peer_port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ack_required": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"exclusive": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"prefetch_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"arguments_json": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadConsumers(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	vhost := d.Get("vhost").(string)
+	user := d.Get("user").(string)
+	queue := d.Get("queue").(string)
+
+	consumerInfos, err := listConsumers(rmqc, vhost, queue)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	consumerTags := []string{}
+	consumers := []map[string]interface{}{}
+	for _, consumer := range consumerInfos {
+		if user != "" && consumer.ChannelDetails.User != user {
+			continue
+		}
+
+		bytes, err := json.Marshal(consumer.Arguments)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		consumerTags = append(consumerTags, consumer.ConsumerTag)
+		consumers = append(consumers, map[string]interface{}{
+			"consumer_tag":   consumer.ConsumerTag,
+			"queue":          consumer.Queue.Name,
+			"vhost":          consumer.Queue.Vhost,
+			"user":           consumer.ChannelDetails.User,
+			"channel":        consumer.ChannelDetails.Name,
+			"connection":     consumer.ChannelDetails.ConnectionName,
+			"node":           consumer.ChannelDetails.Node,
+			"peer_host":      consumer.ChannelDetails.PeerHost,
+			"peer_port":      consumer.ChannelDetails.PeerPort,
+			"ack_required":   bool(consumer.AcknowledgementMode),
+			"exclusive":      consumer.Exclusive,
+			"prefetch_count": consumer.PrefetchCount,
+			"arguments_json": string(bytes),
+		})
+	}
+
+	d.Set("consumer_tags", consumerTags)
+	if err := d.Set("consumers", consumers); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s/%s", user, vhost, queue))
+
+	return diags
+}
+
+// listConsumers lists the consumers of a vhost, or of all the vhosts when it
+// is empty, optionally restricted to a single queue.
+func listConsumers(rmqc *rabbithole.Client, vhost string, queue string) ([]rabbithole.ConsumerInfo, error) {
+	var consumerInfos []rabbithole.ConsumerInfo
+	var err error
+	if vhost != "" {
+		consumerInfos, err = rmqc.ListConsumersIn(vhost)
+	} else {
+		consumerInfos, err = rmqc.ListConsumers()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %d consumers retrieved in %q", len(consumerInfos), vhost)
+
+	if queue == "" {
+		return consumerInfos, nil
+	}
+
+	consumers := []rabbithole.ConsumerInfo{}
+	for _, consumer := range consumerInfos {
+		if consumer.Queue.Name == queue {
+			consumers = append(consumers, consumer)
+		}
+	}
+
+	return consumers, nil
+}
@@ -0,0 +1,431 @@
+package rabbitmq
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesDefinitions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadDefinitions,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"redact_password_hashes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"redact_uri_credentials": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"json": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"rabbitmq_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"hashing_algorithm": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"vhosts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"configure": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"write": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"read": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"queues": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"durable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"auto_delete": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"arguments_json": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"exchanges": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"durable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"auto_delete": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"internal": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"arguments_json": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"bindings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"destination": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"destination_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"routing_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"arguments_json": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"policies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pattern": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"apply_to": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"definition_json": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"parameters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"component": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						// The values of shovels and federation upstreams
+						// hold credentials
+						"value_json": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadDefinitions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta)
+
+	vhost := d.Get("vhost").(string)
+
+	defs, err := getDefinitions(rmqc, vhost)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Definitions retrieved for %q", vhost)
+
+	if d.Get("redact_password_hashes").(bool) {
+		defs.redactPasswordHashes()
+	}
+	if d.Get("redact_uri_credentials").(bool) {
+		defs.redactURICredentials()
+	}
+
+	bytes, err := json.Marshal(defs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("json", string(bytes))
+
+	version := definitionString(defs, "rabbitmq_version")
+	if version == "" {
+		version = definitionString(defs, "rabbit_version")
+	}
+	d.Set("rabbitmq_version", version)
+
+	// The objects of a vhost export don't have a vhost field
+	vhostOf := func(object map[string]interface{}) string {
+		if v := definitionString(object, "vhost"); v != "" {
+			return v
+		}
+		return vhost
+	}
+
+	users := []map[string]interface{}{}
+	for _, user := range defs.objects("users") {
+		users = append(users, map[string]interface{}{
+			"name":              definitionString(user, "name"),
+			"tags":              definitionTags(user),
+			"hashing_algorithm": definitionString(user, "hashing_algorithm"),
+		})
+	}
+
+	vhosts := []map[string]interface{}{}
+	for _, v := range defs.objects("vhosts") {
+		vhosts = append(vhosts, map[string]interface{}{
+			"name": definitionString(v, "name"),
+		})
+	}
+
+	permissions := []map[string]interface{}{}
+	for _, permission := range defs.objects("permissions") {
+		permissions = append(permissions, map[string]interface{}{
+			"user":      definitionString(permission, "user"),
+			"vhost":     vhostOf(permission),
+			"configure": definitionString(permission, "configure"),
+			"write":     definitionString(permission, "write"),
+			"read":      definitionString(permission, "read"),
+		})
+	}
+
+	queues := []map[string]interface{}{}
+	for _, queue := range defs.objects("queues") {
+		arguments, err := definitionJSON(queue, "arguments")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		queues = append(queues, map[string]interface{}{
+			"name":           definitionString(queue, "name"),
+			"vhost":          vhostOf(queue),
+			"durable":        definitionBool(queue, "durable"),
+			"auto_delete":    definitionBool(queue, "auto_delete"),
+			"arguments_json": arguments,
+		})
+	}
+
+	exchanges := []map[string]interface{}{}
+	for _, exchange := range defs.objects("exchanges") {
+		arguments, err := definitionJSON(exchange, "arguments")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		exchanges = append(exchanges, map[string]interface{}{
+			"name":           definitionString(exchange, "name"),
+			"vhost":          vhostOf(exchange),
+			"type":           definitionString(exchange, "type"),
+			"durable":        definitionBool(exchange, "durable"),
+			"auto_delete":    definitionBool(exchange, "auto_delete"),
+			"internal":       definitionBool(exchange, "internal"),
+			"arguments_json": arguments,
+		})
+	}
+
+	bindings := []map[string]interface{}{}
+	for _, binding := range defs.objects("bindings") {
+		arguments, err := definitionJSON(binding, "arguments")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		bindings = append(bindings, map[string]interface{}{
+			"vhost":            vhostOf(binding),
+			"source":           definitionString(binding, "source"),
+			"destination":      definitionString(binding, "destination"),
+			"destination_type": definitionString(binding, "destination_type"),
+			"routing_key":      definitionString(binding, "routing_key"),
+			"arguments_json":   arguments,
+		})
+	}
+
+	policies := []map[string]interface{}{}
+	for _, policy := range defs.objects("policies") {
+		definition, err := definitionJSON(policy, "definition")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		policies = append(policies, map[string]interface{}{
+			"name":            definitionString(policy, "name"),
+			"vhost":           vhostOf(policy),
+			"pattern":         definitionString(policy, "pattern"),
+			"apply_to":        definitionString(policy, "apply-to"),
+			"priority":        definitionInt(policy, "priority"),
+			"definition_json": definition,
+		})
+	}
+
+	parameters := []map[string]interface{}{}
+	for _, parameter := range defs.objects("parameters") {
+		value, err := definitionJSON(parameter, "value")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		parameters = append(parameters, map[string]interface{}{
+			"component":  definitionString(parameter, "component"),
+			"name":       definitionString(parameter, "name"),
+			"vhost":      vhostOf(parameter),
+			"value_json": value,
+		})
+	}
+
+	for key, value := range map[string]interface{}{
+		"users":       users,
+		"vhosts":      vhosts,
+		"permissions": permissions,
+		"queues":      queues,
+		"exchanges":   exchanges,
+		"bindings":    bindings,
+		"policies":    policies,
+		"parameters":  parameters,
+	} {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	hash := sha256.Sum256(bytes)
+	d.SetId(hex.EncodeToString(hash[:]))
+
+	return diags
+}
@@ -0,0 +1,77 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDefinitions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDefinitionsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_definitions.vhost", "queues.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_definitions.vhost", "queues.0.name", "test"),
+					resource.TestCheckResourceAttr("data.rabbitmq_definitions.vhost", "queues.0.vhost", "test"),
+					resource.TestCheckResourceAttr("data.rabbitmq_definitions.vhost", "policies.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_definitions.vhost", "policies.0.apply_to", "queues"),
+					resource.TestCheckResourceAttr("data.rabbitmq_definitions.vhost", "users.#", "0"),
+					resource.TestMatchResourceAttr("data.rabbitmq_definitions.global", "json", regexp.MustCompile(`"name":"mctest"`)),
+					resource.TestCheckResourceAttrWith("data.rabbitmq_definitions.global", "json", func(value string) error {
+						if strings.Contains(value, "password_hash") {
+							return fmt.Errorf("the password hashes were not redacted")
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceDefinitionsConfig = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    vhost = rabbitmq_vhost.test.name
+    settings {
+        durable = true
+    }
+}
+
+resource "rabbitmq_policy" "test" {
+    name = "test"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = ".*"
+        priority = 0
+        apply_to = "queues"
+        definition = {
+            max-length = 1000
+        }
+    }
+}
+
+data "rabbitmq_definitions" "vhost" {
+    vhost = rabbitmq_vhost.test.name
+    depends_on = [rabbitmq_queue.test, rabbitmq_policy.test]
+}
+
+data "rabbitmq_definitions" "global" {
+    depends_on = [rabbitmq_user.test]
+}`
@@ -0,0 +1,184 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesEffectivePolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadEffectivePolicy,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+			},
+			"queue": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"queue", "exchange"},
+			},
+			"exchange": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"queue", "exchange"},
+			},
+			"policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"policy_definition_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"operator_policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"operator_policy_definition_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"effective_definition_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcesReadEffectivePolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta)
+
+	vhost := d.Get("vhost").(string)
+
+	var kind, name string
+	var queue *queueDetails
+	var exchange *exchangeDetails
+	if v, ok := d.GetOk("queue"); ok {
+		kind = "queue"
+		name = v.(string)
+
+		var err error
+		queue, err = getQueueDetails(rmqc, vhost, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		kind = "exchange"
+		name = d.Get("exchange").(string)
+
+		exchange = &exchangeDetails{}
+		if _, err := apiRequest(rmqc, "GET", "exchanges/"+url.PathEscape(vhost)+"/"+url.PathEscape(name), nil, exchange); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	policies, err := rmqc.ListPoliciesIn(vhost)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The policies reported by the server are the ones it actually applies,
+	// they are not computed from the patterns since RabbitMQ patterns are
+	// PCRE, which Go regular expressions don't fully support. An exchange
+	// only reports a policy when one applies, and operator policies only
+	// apply to queues.
+	var policy, operatorPolicy *rabbithole.Policy
+	if queue != nil {
+		policy = findPolicy(policies, queue.Policy)
+
+		operatorPolicies, err := rmqc.ListOperatorPoliciesIn(vhost)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, p := range operatorPolicies {
+			if p.Name == queue.OperatorPolicy {
+				// Operator policies have the same shape as policies
+				operatorPolicy = &rabbithole.Policy{
+					Vhost:      p.Vhost,
+					Pattern:    p.Pattern,
+					ApplyTo:    p.ApplyTo,
+					Name:       p.Name,
+					Priority:   p.Priority,
+					Definition: p.Definition,
+				}
+			}
+		}
+	} else {
+		policy = findPolicy(policies, exchange.Policy)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Effective policies for %s %s@%s: %#v, %#v", kind, name, vhost, policy, operatorPolicy)
+
+	policyDefinition := map[string]interface{}{}
+	if policy != nil {
+		d.Set("policy", policy.Name)
+		policyDefinition = policy.Definition
+	} else {
+		d.Set("policy", "")
+	}
+
+	operatorPolicyDefinition := map[string]interface{}{}
+	if operatorPolicy != nil {
+		d.Set("operator_policy", operatorPolicy.Name)
+		operatorPolicyDefinition = operatorPolicy.Definition
+	} else {
+		d.Set("operator_policy", "")
+	}
+
+	effectiveDefinition := mergePolicyDefinitions(policyDefinition, operatorPolicyDefinition)
+	if queue != nil && len(queue.EffectivePolicyDefinition) > 0 {
+		effectiveDefinition = queue.EffectivePolicyDefinition
+	}
+
+	for key, definition := range map[string]map[string]interface{}{
+		"policy_definition_json":          policyDefinition,
+		"operator_policy_definition_json": operatorPolicyDefinition,
+		"effective_definition_json":       effectiveDefinition,
+	} {
+		bytes, err := json.Marshal(definition)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set(key, string(bytes))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", percentEncodeSlashes(vhost), kind, percentEncodeSlashes(name)))
+
+	return diags
+}
+
+// exchangeDetails adds the policy applied to an exchange, which rabbit-hole
+// doesn't decode.
+type exchangeDetails struct {
+	rabbithole.DetailedExchangeInfo
+	Policy string `json:"policy"`
+}
+
+func findPolicy(policies []rabbithole.Policy, name string) *rabbithole.Policy {
+	if name == "" {
+		return nil
+	}
+	for i := range policies {
+		if policies[i].Name == name {
+			return &policies[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceEffectivePolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceEffectivePolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.queue", "policy", "high"),
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.queue", "operator_policy", "limits"),
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.queue", "effective_definition_json", `{"max-length":100}`),
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.exchange", "policy", "low"),
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.exchange", "operator_policy", ""),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceEffectivePolicyConfig = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    vhost = rabbitmq_vhost.test.name
+    settings {
+        durable = true
+    }
+}
+
+resource "rabbitmq_exchange" "test" {
+    name = "test"
+    vhost = rabbitmq_vhost.test.name
+    settings {
+        type = "fanout"
+    }
+}
+
+resource "rabbitmq_policy" "low" {
+    name = "low"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = ".*"
+        priority = 0
+        apply_to = "all"
+        definition = {
+            alternate-exchange = "amq.fanout"
+        }
+    }
+}
+
+resource "rabbitmq_policy" "high" {
+    name = "high"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = "^test$"
+        priority = 10
+        apply_to = "queues"
+        definition = {
+            max-length = 1000
+        }
+    }
+}
+
+resource "rabbitmq_operator_policy" "limits" {
+    name = "limits"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = ".*"
+        priority = 0
+        apply_to = "queues"
+        definition = {
+            max-length = 100
+        }
+    }
+}
+
+data "rabbitmq_effective_policy" "queue" {
+    vhost = rabbitmq_vhost.test.name
+    queue = rabbitmq_queue.test.name
+    depends_on = [rabbitmq_policy.low, rabbitmq_policy.high, rabbitmq_operator_policy.limits]
+}
+
+data "rabbitmq_effective_policy" "exchange" {
+    vhost = rabbitmq_vhost.test.name
+    exchange = rabbitmq_exchange.test.name
+    depends_on = [rabbitmq_policy.low, rabbitmq_policy.high, rabbitmq_operator_policy.limits]
+}`
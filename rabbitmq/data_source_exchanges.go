@@ -0,0 +1,153 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcesExchanges() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadExchanges,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"durable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"arguments": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"exchanges": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"durable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"auto_delete": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"internal": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"arguments_json": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadExchanges(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	vhost := d.Get("vhost").(string)
+	exchangeType := d.Get("type").(string)
+	durable := optionalBoolFilter(d, "durable")
+	arguments := d.Get("arguments").(map[string]interface{})
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	exchangeInfos, err := rmqc.ListExchangesIn(vhost)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %d exchanges retrieved in %s", len(exchangeInfos), vhost)
+
+	names := []string{}
+	exchanges := []map[string]interface{}{}
+	for _, exchange := range exchangeInfos {
+		if nameRegex != nil && !nameRegex.MatchString(exchange.Name) {
+			continue
+		}
+		if exchangeType != "" && exchange.Type != exchangeType {
+			continue
+		}
+		if durable != nil && exchange.Durable != *durable {
+			continue
+		}
+		if !argumentsMatch(exchange.Arguments, arguments) {
+			continue
+		}
+
+		bytes, err := json.Marshal(exchange.Arguments)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		names = append(names, exchange.Name)
+		exchanges = append(exchanges, map[string]interface{}{
+			"name":           exchange.Name,
+			"vhost":          exchange.Vhost,
+			"type":           exchange.Type,
+			"durable":        exchange.Durable,
+			"auto_delete":    bool(exchange.AutoDelete),
+			"internal":       exchange.Internal,
+			"arguments_json": string(bytes),
+		})
+	}
+
+	d.Set("names", names)
+	if err := d.Set("exchanges", exchanges); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(vhost)
+
+	return diags
+}
@@ -0,0 +1,120 @@
+package rabbitmq
+
+import (
+	"context"
+	"log"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcesFeatureFlags() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadFeatureFlags,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(rabbithole.StateEnabled),
+					string(rabbithole.StateDisabled),
+					string(rabbithole.StateUnsupported),
+				}, false),
+			},
+			"stability": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"feature_flags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"doc_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"stability": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"provided_by": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadFeatureFlags(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	state := d.Get("state").(string)
+	stability := d.Get("stability").(string)
+
+	flags, err := rmqc.ListFeatureFlags()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %d feature flags retrieved", len(flags))
+
+	names := []string{}
+	featureFlags := []map[string]interface{}{}
+	for _, flag := range flags {
+		if state != "" && string(flag.State) != state {
+			continue
+		}
+		if stability != "" && string(flag.Stability) != stability {
+			continue
+		}
+
+		names = append(names, flag.Name)
+		featureFlags = append(featureFlags, map[string]interface{}{
+			"name":        flag.Name,
+			"description": flag.Desc,
+			"doc_url":     flag.DocURL,
+			"state":       string(flag.State),
+			"stability":   string(flag.Stability),
+			"provided_by": flag.ProvidedBy,
+		})
+	}
+
+	d.Set("names", names)
+	if err := d.Set("feature_flags", featureFlags); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("feature_flags")
+
+	return diags
+}
@@ -0,0 +1,178 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// healthChecks lists the supported checks in the order they are run. Each of
+// them returns whether it passed and, if not, the reason reported by RabbitMQ.
+var healthChecks = []struct {
+	name string
+	run  func(rmqc *rabbithole.Client, d *schema.ResourceData) (bool, string, error)
+}{
+	{"alarms", func(rmqc *rabbithole.Client, d *schema.ResourceData) (bool, string, error) {
+		status, err := rmqc.HealthCheckAlarms()
+		return status.Ok(), alarmsReason(status), err
+	}},
+	{"local-alarms", func(rmqc *rabbithole.Client, d *schema.ResourceData) (bool, string, error) {
+		status, err := rmqc.HealthCheckLocalAlarms()
+		return status.Ok(), alarmsReason(status), err
+	}},
+	{"certificate-expiration", func(rmqc *rabbithole.Client, d *schema.ResourceData) (bool, string, error) {
+		within := uint(d.Get("certificate_expiration_within").(int))
+		unit := rabbithole.TimeUnit(d.Get("certificate_expiration_unit").(string))
+		status, err := rmqc.HealthCheckCertificateExpiration(within, unit)
+		return status.Ok(), status.Reason, err
+	}},
+	{"port-listener", func(rmqc *rabbithole.Client, d *schema.ResourceData) (bool, string, error) {
+		status, err := rmqc.HealthCheckPortListener(uint(d.Get("port").(int)))
+		return status.Ok(), status.Reason, err
+	}},
+	{"virtual-hosts", func(rmqc *rabbithole.Client, d *schema.ResourceData) (bool, string, error) {
+		status, err := rmqc.HealthCheckVirtualHosts()
+		return status.Ok(), status.Reason, err
+	}},
+	{"node-is-quorum-critical", func(rmqc *rabbithole.Client, d *schema.ResourceData) (bool, string, error) {
+		status, err := rmqc.HealthCheckNodeIsQuorumCritical()
+		return status.Ok(), status.Reason, err
+	}},
+}
+
+func dataSourcesHealthCheck() *schema.Resource {
+	var names []string
+	for _, check := range healthChecks {
+		names = append(names, check.name)
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourcesReadHealthCheck,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"checks": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(names, false),
+				},
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5672,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"certificate_expiration_within": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"certificate_expiration_unit": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "months",
+				ValidateFunc: validation.StringInSlice([]string{
+					"days", "weeks", "months", "years",
+				}, false),
+			},
+			"healthy": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"failed_checks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"passed": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadHealthCheck(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	selected := d.Get("checks").(*schema.Set)
+
+	failed := []string{}
+	results := []map[string]interface{}{}
+	for _, check := range healthChecks {
+		if selected.Len() > 0 && !selected.Contains(check.name) {
+			continue
+		}
+
+		passed, reason, err := check.run(rmqc, d)
+		if err != nil {
+			return diag.Errorf("Error running RabbitMQ health check %s: %s", check.name, err)
+		}
+
+		log.Printf("[DEBUG] RabbitMQ: Health check %s passed: %t %s", check.name, passed, reason)
+
+		if !passed {
+			failed = append(failed, check.name)
+		}
+		results = append(results, map[string]interface{}{
+			"name":   check.name,
+			"passed": passed,
+			"reason": reason,
+		})
+	}
+
+	d.Set("healthy", len(failed) == 0)
+	d.Set("failed_checks", failed)
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("health")
+
+	return diags
+}
+
+// alarmsReason adds the alarms in effect to the reason of a failed alarms check.
+func alarmsReason(status rabbithole.ResourceAlarmCheckStatus) string {
+	if len(status.Alarms) == 0 {
+		return status.Reason
+	}
+
+	var alarms []string
+	for _, alarm := range status.Alarms {
+		alarms = append(alarms, fmt.Sprintf("%s on %s", alarm.Resource, alarm.Node))
+	}
+
+	return fmt.Sprintf("%s: %s", status.Reason, strings.Join(alarms, ", "))
+}
@@ -0,0 +1,36 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceHealthCheck(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceHealthCheckConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_health_check.all", "healthy", "true"),
+					resource.TestCheckResourceAttr("data.rabbitmq_health_check.all", "results.#", "6"),
+					resource.TestCheckResourceAttr("data.rabbitmq_health_check.all", "failed_checks.#", "0"),
+					resource.TestCheckResourceAttr("data.rabbitmq_health_check.port", "healthy", "false"),
+					resource.TestCheckResourceAttr("data.rabbitmq_health_check.port", "results.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_health_check.port", "failed_checks.0", "port-listener"),
+					resource.TestCheckResourceAttrSet("data.rabbitmq_health_check.port", "results.0.reason"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceHealthCheckConfig = `
+data "rabbitmq_health_check" "all" {}
+
+data "rabbitmq_health_check" "port" {
+    checks = ["port-listener"]
+    port = 1
+}`
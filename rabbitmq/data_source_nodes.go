@@ -0,0 +1,148 @@
+package rabbitmq
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesNodes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadNodes,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"all_running": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"any_alarm": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"any_partitioned": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"nodes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"running": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"mem_used": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"mem_limit": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"mem_alarm": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"disk_free": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"disk_free_limit": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"disk_free_alarm": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"uptime": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"partitions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadNodes(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	nodeInfos, err := rmqc.ListNodes()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %d nodes retrieved", len(nodeInfos))
+
+	allRunning := true
+	anyAlarm := false
+	anyPartitioned := false
+
+	names := []string{}
+	nodes := []map[string]interface{}{}
+	for _, node := range nodeInfos {
+		allRunning = allRunning && node.IsRunning
+		anyAlarm = anyAlarm || node.MemAlarm || node.DiskFreeAlarm
+		anyPartitioned = anyPartitioned || len(node.Partitions) > 0
+
+		partitions := node.Partitions
+		if partitions == nil {
+			partitions = []string{}
+		}
+
+		names = append(names, node.Name)
+		nodes = append(nodes, map[string]interface{}{
+			"name":            node.Name,
+			"type":            node.NodeType,
+			"running":         node.IsRunning,
+			"mem_used":        node.MemUsed,
+			"mem_limit":       node.MemLimit,
+			"mem_alarm":       node.MemAlarm,
+			"disk_free":       node.DiskFree,
+			"disk_free_limit": node.DiskFreeLimit,
+			"disk_free_alarm": node.DiskFreeAlarm,
+			"uptime":          int(node.Uptime),
+			"partitions":      partitions,
+		})
+	}
+
+	d.Set("names", names)
+	d.Set("all_running", allRunning)
+	d.Set("any_alarm", anyAlarm)
+	d.Set("any_partitioned", anyPartitioned)
+	if err := d.Set("nodes", nodes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("nodes")
+
+	return diags
+}
@@ -0,0 +1,224 @@
+package rabbitmq
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesOverview() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadOverview,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"node": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rabbitmq_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"erlang_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"management_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"statistics_level": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"object_totals": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"connections": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"channels": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"exchanges": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"queues": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"consumers": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"queue_totals": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"messages": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"messages_ready": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"messages_unacknowledged": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"message_rates": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"publish": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"deliver_get": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"ack": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"redeliver": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"return_unroutable": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"drop_unroutable": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"listeners": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"node": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadOverview(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	overview, err := rmqc.Overview()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Overview retrieved: %#v", overview)
+
+	clusterName, err := rmqc.GetClusterName()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("cluster_name", clusterName.Name)
+	d.Set("node", overview.Node)
+	d.Set("rabbitmq_version", overview.RabbitMQVersion)
+	d.Set("erlang_version", overview.ErlangVersion)
+	d.Set("management_version", overview.ManagementVersion)
+	d.Set("statistics_level", overview.StatisticsLevel)
+
+	objectTotals := []map[string]interface{}{{
+		"connections": overview.ObjectTotals.Connections,
+		"channels":    overview.ObjectTotals.Channels,
+		"exchanges":   overview.ObjectTotals.Exchanges,
+		"queues":      overview.ObjectTotals.Queues,
+		"consumers":   overview.ObjectTotals.Consumers,
+	}}
+	if err := d.Set("object_totals", objectTotals); err != nil {
+		return diag.FromErr(err)
+	}
+
+	queueTotals := []map[string]interface{}{{
+		"messages":                overview.QueueTotals.Messages,
+		"messages_ready":          overview.QueueTotals.MessagesReady,
+		"messages_unacknowledged": overview.QueueTotals.MessagesUnacknowledged,
+	}}
+	if err := d.Set("queue_totals", queueTotals); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stats := overview.MessageStats
+	messageRates := []map[string]interface{}{{
+		"publish":           float64(stats.PublishDetails.Rate),
+		"deliver_get":       float64(stats.DeliverGetDetails.Rate),
+		"ack":               float64(stats.AckDetails.Rate),
+		"redeliver":         float64(stats.RedeliverDetails.Rate),
+		"return_unroutable": float64(stats.ReturnUnroutableDetails.Rate),
+		"drop_unroutable":   float64(stats.DropUnroutableDetails.Rate),
+	}}
+	if err := d.Set("message_rates", messageRates); err != nil {
+		return diag.FromErr(err)
+	}
+
+	listeners := []map[string]interface{}{}
+	for _, listener := range overview.Listeners {
+		listeners = append(listeners, map[string]interface{}{
+			"node":       listener.Node,
+			"protocol":   listener.Protocol,
+			"ip_address": listener.IpAddress,
+			"port":       int(listener.Port),
+		})
+	}
+	if err := d.Set("listeners", listeners); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(clusterName.Name)
+
+	return diags
+}
@@ -0,0 +1,35 @@
+package rabbitmq
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceOverview(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceOverviewConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.rabbitmq_overview.test", "cluster_name"),
+					resource.TestMatchResourceAttr("data.rabbitmq_overview.test", "rabbitmq_version", regexp.MustCompile(`^\d+\.\d+`)),
+					resource.TestCheckResourceAttr("data.rabbitmq_overview.test", "object_totals.#", "1"),
+					resource.TestMatchResourceAttr("data.rabbitmq_overview.test", "listeners.#", regexp.MustCompile(`^[1-9]`)),
+					resource.TestCheckResourceAttr("data.rabbitmq_nodes.test", "all_running", "true"),
+					resource.TestCheckResourceAttr("data.rabbitmq_nodes.test", "any_alarm", "false"),
+					resource.TestCheckResourceAttr("data.rabbitmq_nodes.test", "any_partitioned", "false"),
+					resource.TestCheckResourceAttrPair("data.rabbitmq_nodes.test", "nodes.0.name", "data.rabbitmq_overview.test", "node"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceOverviewConfig = `
+data "rabbitmq_overview" "test" {}
+
+data "rabbitmq_nodes" "test" {}`
@@ -0,0 +1,72 @@
+package rabbitmq
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcesPasswordHash() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadPasswordHash,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			// The salt has to be provided so that the computed hash is stable
+			// across plans, a random one would produce a diff on every run.
+			"salt": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(passwordHashSaltLength, passwordHashSaltLength),
+			},
+			"hashing_algorithm": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(rabbithole.HashingAlgorithmSHA256),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(rabbithole.HashingAlgorithmSHA256),
+					string(rabbithole.HashingAlgorithmSHA512),
+					string(rabbithole.HashingAlgorithmMD5),
+				}, false),
+			},
+			"password_hash": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourcesReadPasswordHash(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	algorithm := rabbithole.HashingAlgorithm(d.Get("hashing_algorithm").(string))
+
+	hash, err := hashUserPassword(d.Get("password").(string), []byte(d.Get("salt").(string)), algorithm)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("password_hash", hash)
+
+	// The id is not sensitive, it must not disclose the hash
+	id := sha256.Sum256([]byte(string(algorithm) + ":" + d.Get("salt").(string)))
+	d.SetId(hex.EncodeToString(id[:]))
+
+	return diags
+}
@@ -0,0 +1,105 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesPermissions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadPermissions,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"configure": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"write": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"read": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	user := d.Get("user").(string)
+	vhost := d.Get("vhost").(string)
+
+	var permissionInfos []rabbithole.PermissionInfo
+	var err error
+	if user != "" {
+		permissionInfos, err = rmqc.ListPermissionsOf(user)
+	} else {
+		permissionInfos, err = rmqc.ListPermissions()
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %d permissions retrieved", len(permissionInfos))
+
+	permissions := []map[string]interface{}{}
+	for _, permission := range permissionInfos {
+		if vhost != "" && permission.Vhost != vhost {
+			continue
+		}
+
+		permissions = append(permissions, map[string]interface{}{
+			"user":      permission.User,
+			"vhost":     permission.Vhost,
+			"configure": permission.Configure,
+			"write":     permission.Write,
+			"read":      permission.Read,
+		})
+	}
+
+	if err := d.Set("permissions", permissions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", user, vhost))
+
+	return diags
+}
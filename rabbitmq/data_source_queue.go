@@ -0,0 +1,192 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// queueDetails adds the fields of GET /api/queues/{vhost}/{name} which are
+// not part of rabbithole.QueueInfo.
+type queueDetails struct {
+	rabbithole.QueueInfo
+	OperatorPolicy            string                 `json:"operator_policy"`
+	EffectivePolicyDefinition map[string]interface{} `json:"effective_policy_definition"`
+}
+
+func getQueueDetails(rmqc *providerMeta, vhost string, name string) (*queueDetails, error) {
+	queue := &queueDetails{}
+	_, err := apiRequest(rmqc, "GET", "queues/"+url.PathEscape(vhost)+"/"+url.PathEscape(name), nil, queue)
+	if err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+func dataSourcesQueue() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadQueue,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+			},
+			"settings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"durable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"auto_delete": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"exclusive": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"arguments_json": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"messages": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"messages_ready": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"messages_unacknowledged": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"consumers": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"node": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"leader": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"online_members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"operator_policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"effective_policy_definition_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcesReadQueue(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta)
+
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+	id := fmt.Sprintf("%s@%s", name, vhost)
+
+	queue, err := getQueueDetails(rmqc, vhost, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Queue retrieved %s: %#v", id, queue)
+
+	arguments, err := json.Marshal(queue.Arguments)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	settings := make([]map[string]interface{}, 1)
+	settings[0] = map[string]interface{}{
+		"type":           queue.Type,
+		"durable":        queue.Durable,
+		"auto_delete":    bool(queue.AutoDelete),
+		"exclusive":      queue.Exclusive,
+		"arguments_json": string(arguments),
+	}
+	if err := d.Set("settings", settings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	effectivePolicyDefinition, err := json.Marshal(queue.EffectivePolicyDefinition)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", queue.Name)
+	d.Set("vhost", queue.Vhost)
+	d.Set("state", queue.Status)
+	d.Set("messages", queue.Messages)
+	d.Set("messages_ready", queue.MessagesReady)
+	d.Set("messages_unacknowledged", queue.MessagesUnacknowledged)
+	d.Set("consumers", queue.Consumers)
+	d.Set("node", queue.Node)
+	d.Set("leader", queue.Leader)
+	d.Set("members", queue.Members)
+	d.Set("online_members", queue.Online)
+	d.Set("policy", queue.Policy)
+	d.Set("operator_policy", queue.OperatorPolicy)
+	d.Set("effective_policy_definition_json", string(effectivePolicyDefinition))
+
+	d.SetId(id)
+
+	return diags
+}
@@ -0,0 +1,63 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceQueue(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceQueueConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_queue.test", "settings.0.type", "quorum"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queue.test", "settings.0.durable", "true"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queue.test", "messages_ready", "0"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queue.test", "consumers", "0"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queue.test", "members.#", "1"),
+					resource.TestCheckResourceAttrSet("data.rabbitmq_queue.test", "leader"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queue.test", "policy", "test"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceQueueConfig = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    vhost = rabbitmq_vhost.test.name
+    settings {
+        durable = true
+        arguments = {
+            "x-queue-type" = "quorum"
+        }
+    }
+}
+
+resource "rabbitmq_policy" "test" {
+    name = "test"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = "^test$"
+        priority = 0
+        apply_to = "queues"
+        definition = {
+            max-length = 1000
+        }
+    }
+}
+
+data "rabbitmq_queue" "test" {
+    name = rabbitmq_queue.test.name
+    vhost = rabbitmq_vhost.test.name
+    depends_on = [rabbitmq_policy.test]
+}`
@@ -0,0 +1,154 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcesQueues() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadQueues,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"classic", "quorum", "stream"}, false),
+			},
+			"durable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"arguments": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"queues": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"durable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"auto_delete": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"exclusive": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"arguments_json": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadQueues(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	vhost := d.Get("vhost").(string)
+	queueType := d.Get("type").(string)
+	durable := optionalBoolFilter(d, "durable")
+	arguments := d.Get("arguments").(map[string]interface{})
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	queueInfos, err := rmqc.ListQueuesIn(vhost)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %d queues retrieved in %s", len(queueInfos), vhost)
+
+	names := []string{}
+	queues := []map[string]interface{}{}
+	for _, queue := range queueInfos {
+		if nameRegex != nil && !nameRegex.MatchString(queue.Name) {
+			continue
+		}
+		if queueType != "" && queue.Type != queueType {
+			continue
+		}
+		if durable != nil && queue.Durable != *durable {
+			continue
+		}
+		if !argumentsMatch(queue.Arguments, arguments) {
+			continue
+		}
+
+		bytes, err := json.Marshal(queue.Arguments)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		names = append(names, queue.Name)
+		queues = append(queues, map[string]interface{}{
+			"name":           queue.Name,
+			"vhost":          queue.Vhost,
+			"type":           queue.Type,
+			"durable":        queue.Durable,
+			"auto_delete":    bool(queue.AutoDelete),
+			"exclusive":      queue.Exclusive,
+			"arguments_json": string(bytes),
+		})
+	}
+
+	d.Set("names", names)
+	if err := d.Set("queues", queues); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(vhost)
+
+	return diags
+}
@@ -0,0 +1,106 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceQueues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceQueuesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_queues.all", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queues.quorum", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queues.quorum", "queues.0.name", "test-quorum"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queues.quorum", "queues.0.type", "quorum"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queues.non_durable", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queues.non_durable", "names.0", "test-classic"),
+					resource.TestCheckResourceAttr("data.rabbitmq_exchanges.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_exchanges.test", "exchanges.0.type", "topic"),
+					resource.TestCheckResourceAttr("data.rabbitmq_bindings.test", "bindings.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_bindings.test", "bindings.0.destination", "test-quorum"),
+					resource.TestCheckResourceAttr("data.rabbitmq_bindings.test", "bindings.0.routing_key", "foo.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceQueuesConfig = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_queue" "classic" {
+    name = "test-classic"
+    vhost = rabbitmq_vhost.test.name
+    settings {
+        durable = false
+    }
+}
+
+resource "rabbitmq_queue" "quorum" {
+    name = "test-quorum"
+    vhost = rabbitmq_vhost.test.name
+    settings {
+        durable = true
+        arguments = {
+            "x-queue-type" = "quorum"
+        }
+    }
+}
+
+resource "rabbitmq_exchange" "test" {
+    name = "test-exchange"
+    vhost = rabbitmq_vhost.test.name
+    settings {
+        type = "topic"
+        durable = true
+    }
+}
+
+resource "rabbitmq_binding" "test" {
+    source = rabbitmq_exchange.test.name
+    vhost = rabbitmq_vhost.test.name
+    destination = rabbitmq_queue.quorum.name
+    destination_type = "queue"
+    routing_key = "foo.#"
+}
+
+data "rabbitmq_queues" "all" {
+    vhost = rabbitmq_vhost.test.name
+    depends_on = [rabbitmq_queue.classic, rabbitmq_queue.quorum]
+}
+
+data "rabbitmq_queues" "quorum" {
+    vhost = rabbitmq_vhost.test.name
+    name_regex = "^test-"
+    arguments = {
+        "x-queue-type" = "quorum"
+    }
+    depends_on = [rabbitmq_queue.classic, rabbitmq_queue.quorum]
+}
+
+data "rabbitmq_queues" "non_durable" {
+    vhost = rabbitmq_vhost.test.name
+    durable = false
+    depends_on = [rabbitmq_queue.classic, rabbitmq_queue.quorum]
+}
+
+data "rabbitmq_exchanges" "test" {
+    vhost = rabbitmq_vhost.test.name
+    name_regex = "^test-"
+    depends_on = [rabbitmq_exchange.test]
+}
+
+data "rabbitmq_bindings" "test" {
+    vhost = rabbitmq_vhost.test.name
+    source_regex = "^test-exchange$"
+    destination_type = "queue"
+    depends_on = [rabbitmq_binding.test]
+}`
@@ -0,0 +1,105 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesTopicPermissions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadTopicPermissions,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vhost": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"exchange": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"write": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"read": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadTopicPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	user := d.Get("user").(string)
+	vhost := d.Get("vhost").(string)
+
+	var permissionInfos []rabbithole.TopicPermissionInfo
+	var err error
+	if user != "" {
+		permissionInfos, err = rmqc.ListTopicPermissionsOf(user)
+	} else {
+		permissionInfos, err = rmqc.ListTopicPermissions()
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %d topic permissions retrieved", len(permissionInfos))
+
+	permissions := []map[string]interface{}{}
+	for _, permission := range permissionInfos {
+		if vhost != "" && permission.Vhost != vhost {
+			continue
+		}
+
+		permissions = append(permissions, map[string]interface{}{
+			"user":     permission.User,
+			"vhost":    permission.Vhost,
+			"exchange": permission.Exchange,
+			"write":    permission.Write,
+			"read":     permission.Read,
+		})
+	}
+
+	if err := d.Set("permissions", permissions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", user, vhost))
+
+	return diags
+}
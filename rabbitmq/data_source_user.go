@@ -4,8 +4,6 @@ import (
 	"context"
 	"log"
 
-	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -27,6 +25,11 @@ func dataSourcesUser() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Computed: true,
 			},
+			"vhosts": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
 		},
 	}
 }
@@ -34,7 +37,7 @@ func dataSourcesUser() *schema.Resource {
 func dataSourcesReadUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name := d.Get("name").(string)
 
@@ -59,6 +62,17 @@ func dataSourcesReadUser(ctx context.Context, d *schema.ResourceData, meta inter
 		}
 	}
 
+	permissions, err := rmqc.ListPermissionsOf(name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vhosts := []string{}
+	for _, permission := range permissions {
+		vhosts = append(vhosts, permission.Vhost)
+	}
+	d.Set("vhosts", vhosts)
+
 	d.SetId(name)
 
 	return diags
@@ -0,0 +1,48 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesUserLimits() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadUserLimits,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"max_connections": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"max_channels": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcesReadUserLimits(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	user := d.Get("user").(string)
+
+	if err := userLimits.readValues(rmqc, user, d); err != nil {
+		return diag.FromErr(checkDeleted(d, err))
+	}
+
+	d.SetId(user)
+
+	return diags
+}
@@ -0,0 +1,119 @@
+package rabbitmq
+
+import (
+	"context"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcesUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadUsers,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"hashing_algorithm": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"passwordless": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadUsers(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	tags := d.Get("tags").(*schema.Set)
+
+	userInfos, err := rmqc.ListUsers()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %d users retrieved", len(userInfos))
+
+	names := []string{}
+	users := []map[string]interface{}{}
+	for _, user := range userInfos {
+		if nameRegex != nil && !nameRegex.MatchString(user.Name) {
+			continue
+		}
+
+		userTags := schema.NewSet(schema.HashString, nil)
+		tagList := []string{}
+		for _, v := range user.Tags {
+			if v != "" {
+				userTags.Add(v)
+				tagList = append(tagList, v)
+			}
+		}
+		if tags.Difference(userTags).Len() > 0 {
+			continue
+		}
+
+		names = append(names, user.Name)
+		users = append(users, map[string]interface{}{
+			"name":              user.Name,
+			"tags":              tagList,
+			"hashing_algorithm": user.HashingAlgorithm.String(),
+			"passwordless":      user.PasswordHash == "",
+		})
+	}
+
+	d.Set("names", names)
+	if err := d.Set("users", users); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("users")
+
+	return diags
+}
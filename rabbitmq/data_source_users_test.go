@@ -0,0 +1,86 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceUsers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUsersConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_users.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_users.test", "users.0.name", "mctest"),
+					resource.TestCheckResourceAttr("data.rabbitmq_user.test", "vhosts.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_user.test", "vhosts.0", "test"),
+					resource.TestCheckResourceAttr("data.rabbitmq_permissions.by_user", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_permissions.by_user", "permissions.0.configure", ".*"),
+					resource.TestCheckResourceAttr("data.rabbitmq_permissions.by_vhost", "permissions.0.user", "mctest"),
+					resource.TestCheckResourceAttr("data.rabbitmq_topic_permissions.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_topic_permissions.test", "permissions.0.exchange", "amq.topic"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceUsersConfig = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+    tags = ["monitoring"]
+}
+
+resource "rabbitmq_permissions" "test" {
+    user = rabbitmq_user.test.name
+    vhost = rabbitmq_vhost.test.name
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_topic_permission" "test" {
+    user = rabbitmq_user.test.name
+    vhost = rabbitmq_vhost.test.name
+    exchange = "amq.topic"
+    write = ".*"
+    read = ".*"
+}
+
+data "rabbitmq_users" "test" {
+    name_regex = "^mc"
+    tags = ["monitoring"]
+    depends_on = [rabbitmq_user.test]
+}
+
+data "rabbitmq_user" "test" {
+    name = rabbitmq_user.test.name
+    depends_on = [rabbitmq_permissions.test]
+}
+
+data "rabbitmq_permissions" "by_user" {
+    user = rabbitmq_user.test.name
+    depends_on = [rabbitmq_permissions.test]
+}
+
+data "rabbitmq_permissions" "by_vhost" {
+    vhost = rabbitmq_vhost.test.name
+    depends_on = [rabbitmq_permissions.test]
+}
+
+data "rabbitmq_topic_permissions" "test" {
+    user = rabbitmq_user.test.name
+    vhost = rabbitmq_vhost.test.name
+    depends_on = [rabbitmq_topic_permission.test]
+}`
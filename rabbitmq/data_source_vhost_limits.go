@@ -0,0 +1,48 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesVhostLimits() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcesReadVhostLimits,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"max_connections": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"max_queues": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcesReadVhostLimits(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*providerMeta).Client
+
+	vhost := d.Get("vhost").(string)
+
+	if err := vhostLimits.readValues(rmqc, vhost, d); err != nil {
+		return diag.FromErr(checkDeleted(d, err))
+	}
+
+	d.SetId(vhost)
+
+	return diags
+}
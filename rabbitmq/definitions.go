@@ -0,0 +1,362 @@
+package rabbitmq
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Definitions are handled as generic JSON documents rather than with
+// rabbithole.ExportedDefinitions, which misses some fields and sections, so
+// that they can be exported and imported back without loss.
+type definitions map[string]interface{}
+
+// getDefinitions exports the definitions of the whole cluster, or of a single
+// vhost when it is not empty.
+func getDefinitions(rmqc *providerMeta, vhost string) (definitions, error) {
+	path := "definitions"
+	if vhost != "" {
+		path = "definitions/" + url.PathEscape(vhost)
+	}
+
+	defs := definitions{}
+	if _, err := apiRequest(rmqc, "GET", path, nil, &defs); err != nil {
+		return nil, err
+	}
+
+	return defs, nil
+}
+
+// objects returns the objects of a section of the definitions, e.g. "queues".
+func (defs definitions) objects(section string) []map[string]interface{} {
+	list, _ := defs[section].([]interface{})
+
+	objects := []map[string]interface{}{}
+	for _, item := range list {
+		if object, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, object)
+		}
+	}
+
+	return objects
+}
+
+// redactPasswordHashes removes the password hashes of the exported users.
+func (defs definitions) redactPasswordHashes() {
+	for _, user := range defs.objects("users") {
+		delete(user, "password_hash")
+	}
+}
+
+// redactURICredentials replaces the passwords of the URIs of the shovel and
+// federation upstream parameters, which may be a single URI or a list.
+func (defs definitions) redactURICredentials() {
+	for _, parameter := range defs.objects("parameters") {
+		value, ok := parameter["value"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for key, uris := range value {
+			if key != "uri" && !strings.HasSuffix(key, "-uri") {
+				continue
+			}
+			switch v := uris.(type) {
+			case string:
+				value[key] = redactURI(v)
+			case []interface{}:
+				for i, uri := range v {
+					if uri, ok := uri.(string); ok {
+						v[i] = redactURI(uri)
+					}
+				}
+			}
+		}
+	}
+}
+
+// redactURI replaces the password of a URI with "xxxxx". A URI which cannot
+// be parsed is redacted entirely.
+func redactURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "xxxxx"
+	}
+
+	return u.Redacted()
+}
+
+func definitionString(object map[string]interface{}, key string) string {
+	switch v := object[key].(type) {
+	case nil:
+		return ""
+	default:
+		return formatValue(v)
+	}
+}
+
+func definitionBool(object map[string]interface{}, key string) bool {
+	v, _ := object[key].(bool)
+	return v
+}
+
+func definitionInt(object map[string]interface{}, key string) int {
+	v, _ := object[key].(float64)
+	return int(v)
+}
+
+// definitionJSON encodes a nested value, such as arguments, as JSON. A
+// missing value is encoded as an empty object.
+func definitionJSON(object map[string]interface{}, key string) (string, error) {
+	value, ok := object[key]
+	if !ok || value == nil {
+		return "{}", nil
+	}
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+// definitionTags returns the tags of a user, which older RabbitMQ versions
+// export as a comma-separated string and newer ones as a list.
+func definitionTags(object map[string]interface{}) []string {
+	tags := []string{}
+	switch v := object["tags"].(type) {
+	case string:
+		for _, tag := range strings.Split(v, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	case []interface{}:
+		for _, tag := range v {
+			if tag, ok := tag.(string); ok && tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return tags
+}
+
+// definitionSections lists the sections of the definitions which hold objects,
+// in the order they are created, with the fields identifying their objects.
+var definitionSections = []struct {
+	name string
+	kind string
+	keys []string
+}{
+	{"vhosts", "vhost", []string{"name"}},
+	{"users", "user", []string{"name"}},
+	{"permissions", "permissions", []string{"user", "vhost"}},
+	{"topic_permissions", "topic_permission", []string{"user", "vhost", "exchange"}},
+	{"global_parameters", "global_parameter", []string{"name"}},
+	{"parameters", "parameter", []string{"component", "vhost", "name"}},
+	{"policies", "policy", []string{"vhost", "name"}},
+	{"operator_policies", "operator_policy", []string{"vhost", "name"}},
+	{"exchanges", "exchange", []string{"vhost", "name"}},
+	{"queues", "queue", []string{"vhost", "name"}},
+	{"bindings", "binding", []string{"vhost", "source", "destination_type", "destination", "routing_key", "arguments"}},
+}
+
+// definitionObjectId identifies an object of a section as
+// "<kind>:<key>/<key>...", e.g. "queue:test/orders". The objects of a vhost
+// export don't have a vhost field, the given vhost is used for them.
+func definitionObjectId(section int, object map[string]interface{}, vhost string) (string, error) {
+	var parts []string
+	for _, key := range definitionSections[section].keys {
+		var value string
+		switch key {
+		case "arguments":
+			arguments, err := definitionJSON(object, key)
+			if err != nil {
+				return "", err
+			}
+			value = arguments
+		case "vhost":
+			if value = definitionString(object, key); value == "" {
+				value = vhost
+			}
+		default:
+			value = definitionString(object, key)
+		}
+		parts = append(parts, percentEncodeSlashes(value))
+	}
+
+	return definitionSections[section].kind + ":" + strings.Join(parts, "/"), nil
+}
+
+// parseDefinitionObjectId returns the kind and the keys of an object id.
+func parseDefinitionObjectId(id string) (string, []string, error) {
+	kind, keys, ok := strings.Cut(id, ":")
+	if !ok {
+		return "", nil, fmt.Errorf("Unable to parse definitions object id: %s", id)
+	}
+
+	parts := strings.Split(keys, "/")
+	for _, section := range definitionSections {
+		if section.kind == kind {
+			if len(parts) != len(section.keys) {
+				return "", nil, fmt.Errorf("Unable to parse definitions object id: %s", id)
+			}
+			for i, part := range parts {
+				parts[i] = percentDecodeSlashes(part)
+			}
+			return kind, parts, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("Unknown kind of definitions object: %s", id)
+}
+
+// ownedObjects returns the ids of the objects of the definitions, in the order
+// they are created. The objects of a section are sorted by id.
+func (defs definitions) ownedObjects(vhost string) ([]string, error) {
+	ids := []string{}
+	for i, section := range definitionSections {
+		var sectionIds []string
+		for _, object := range defs.objects(section.name) {
+			id, err := definitionObjectId(i, object, vhost)
+			if err != nil {
+				return nil, err
+			}
+			sectionIds = append(sectionIds, id)
+		}
+		sort.Strings(sectionIds)
+		ids = append(ids, sectionIds...)
+	}
+
+	return ids, nil
+}
+
+// normalize only keeps the sections holding objects, sorts their objects by
+// id, and makes the representation of the user tags independent of the
+// RabbitMQ version.
+func (defs definitions) normalize() (definitions, error) {
+	normalized := definitions{}
+	for s, section := range definitionSections {
+		objects := defs.objects(section.name)
+		if len(objects) == 0 {
+			continue
+		}
+
+		ids := make([]string, len(objects))
+		for i, object := range objects {
+			id, err := definitionObjectId(s, object, "")
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = id
+		}
+		order := make([]int, len(objects))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return ids[order[i]] < ids[order[j]]
+		})
+
+		list := make([]interface{}, len(objects))
+		for i, index := range order {
+			object := objects[index]
+			if _, ok := object["tags"]; ok && section.name == "users" {
+				copied := make(map[string]interface{})
+				for key, value := range object {
+					copied[key] = value
+				}
+				tags := definitionTags(object)
+				sort.Strings(tags)
+				sorted := make([]interface{}, len(tags))
+				for j, tag := range tags {
+					sorted[j] = tag
+				}
+				copied["tags"] = sorted
+				object = copied
+			}
+			list[i] = object
+		}
+		normalized[section.name] = list
+	}
+
+	return normalized, nil
+}
+
+// project returns the objects of defs as they currently are in the exported
+// definitions: for each of them, the fields of defs are read from the export.
+// The fields which are not exported, such as user passwords, are kept as is,
+// and the objects which don't exist anymore are left out.
+func (defs definitions) project(exported definitions, vhost string) (definitions, error) {
+	projected := definitions{}
+	for i, section := range definitionSections {
+		existing := make(map[string]map[string]interface{})
+		for _, object := range exported.objects(section.name) {
+			id, err := definitionObjectId(i, object, vhost)
+			if err != nil {
+				return nil, err
+			}
+			existing[id] = object
+		}
+
+		list := []interface{}{}
+		for _, object := range defs.objects(section.name) {
+			id, err := definitionObjectId(i, object, vhost)
+			if err != nil {
+				return nil, err
+			}
+
+			current, ok := existing[id]
+			if !ok {
+				continue
+			}
+
+			values := make(map[string]interface{})
+			for key, value := range object {
+				if v, ok := current[key]; ok {
+					value = v
+				}
+				values[key] = value
+			}
+			list = append(list, values)
+		}
+		if len(list) > 0 {
+			projected[section.name] = list
+		}
+	}
+
+	return projected.normalize()
+}
+
+// parseDefinitions decodes a JSON definitions document.
+func parseDefinitions(document string) (definitions, error) {
+	defs := definitions{}
+	if err := json.Unmarshal([]byte(document), &defs); err != nil {
+		return nil, fmt.Errorf("Unable to parse definitions: %s", err)
+	}
+
+	return defs, nil
+}
+
+// definitionsHash hashes the normalized objects of the definitions, which
+// doesn't depend on their order in the document.
+func definitionsHash(defs definitions) (string, error) {
+	normalized, err := defs.normalize()
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,586 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// NewClient configures a client the same way as the provider, from the given
+// provider arguments and the RABBITMQ_* environment variables.
+func NewClient(config map[string]interface{}) (*rabbithole.Client, error) {
+	p := Provider()
+	c := terraform.NewResourceConfigRaw(config)
+
+	diags := p.Validate(c)
+	if !diags.HasError() {
+		diags = p.Configure(context.Background(), c)
+	}
+	if diags.HasError() {
+		var messages []string
+		for _, d := range diags {
+			messages = append(messages, d.Summary)
+		}
+		return nil, errors.New(strings.Join(messages, "; "))
+	}
+
+	return p.Meta().(*providerMeta).Client, nil
+}
+
+// Generate writes the resources of this provider matching the objects of the
+// cluster, or of a single vhost when it is not empty, together with the import
+// blocks bringing them under management.
+func Generate(rmqc *rabbithole.Client, vhost string, w io.Writer) error {
+	g := newHCLGenerator(true)
+
+	inVhost := func(v string) bool {
+		return vhost == "" || v == vhost
+	}
+
+	vhosts, err := rmqc.ListVhosts()
+	if err != nil {
+		return err
+	}
+	for _, v := range vhosts {
+		if inVhost(v.Name) {
+			g.vhost(v.Name)
+		}
+	}
+
+	users, err := rmqc.ListUsers()
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		g.user(user)
+	}
+
+	permissions, err := rmqc.ListPermissions()
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if inVhost(permission.Vhost) {
+			g.permissions(permission)
+		}
+	}
+
+	exchanges, err := rmqc.ListExchanges()
+	if err != nil {
+		return err
+	}
+	for _, exchange := range exchanges {
+		if inVhost(exchange.Vhost) {
+			g.exchange(exchange)
+		}
+	}
+
+	// Topic permissions reference the exchanges
+	topicPermissions, err := rmqc.ListTopicPermissions()
+	if err != nil {
+		return err
+	}
+	for _, permission := range topicPermissions {
+		if inVhost(permission.Vhost) {
+			g.topicPermission(permission)
+		}
+	}
+
+	queues, err := rmqc.ListQueues()
+	if err != nil {
+		return err
+	}
+	for _, queue := range queues {
+		if inVhost(queue.Vhost) {
+			g.queue(queue)
+		}
+	}
+
+	bindings, err := rmqc.ListBindings()
+	if err != nil {
+		return err
+	}
+	for _, binding := range bindings {
+		if inVhost(binding.Vhost) {
+			g.binding(binding)
+		}
+	}
+
+	policies, err := rmqc.ListPolicies()
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		if inVhost(policy.Vhost) {
+			g.policy(policy)
+		}
+	}
+
+	operatorPolicies, err := rmqc.ListOperatorPolicies()
+	if err != nil {
+		return err
+	}
+	for _, policy := range operatorPolicies {
+		if inVhost(policy.Vhost) {
+			g.operatorPolicy(policy)
+		}
+	}
+
+	limits, err := rmqc.GetAllVhostLimits()
+	if err != nil {
+		return err
+	}
+	for _, l := range limits {
+		if inVhost(l.Vhost) {
+			g.vhostLimits(l)
+		}
+	}
+
+	shovels, err := rmqc.ListShovels()
+	if err != nil {
+		return err
+	}
+	for _, shovel := range shovels {
+		if inVhost(shovel.Vhost) {
+			g.shovel(shovel)
+		}
+	}
+
+	upstreams, err := rmqc.ListFederationUpstreams()
+	if err != nil {
+		return err
+	}
+	for _, upstream := range upstreams {
+		if inVhost(upstream.Vhost) {
+			g.federationUpstream(upstream)
+		}
+	}
+
+	_, err = w.Write(g.bytes())
+	return err
+}
+
+// hclGenerator writes resources of this provider. The resources reference the
+// vhosts, users, exchanges and queues generated before them, so that they are
+// created in the right order.
+type hclGenerator struct {
+	file    *hclwrite.File
+	imports bool
+	// resource type -> labels in use
+	labels map[string]map[string]bool
+	// resource type + vhost + name -> label
+	generated map[string]string
+}
+
+func newHCLGenerator(imports bool) *hclGenerator {
+	return &hclGenerator{
+		file:      hclwrite.NewEmptyFile(),
+		imports:   imports,
+		labels:    make(map[string]map[string]bool),
+		generated: make(map[string]string),
+	}
+}
+
+func (g *hclGenerator) bytes() []byte {
+	return g.file.Bytes()
+}
+
+var invalidLabelCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// resource appends a resource block with a unique label derived from the
+// given names, and its import block when the id is not empty.
+func (g *hclGenerator) resource(resourceType string, id string, names ...string) (string, *hclwrite.Body) {
+	label := strings.Trim(invalidLabelCharacters.ReplaceAllString(strings.ToLower(strings.Join(names, "_")), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "_" + label
+	}
+
+	if g.labels[resourceType] == nil {
+		g.labels[resourceType] = make(map[string]bool)
+	}
+	unique := label
+	for i := 2; g.labels[resourceType][unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	g.labels[resourceType][unique] = true
+
+	body := g.file.Body()
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	block := body.AppendNewBlock("resource", []string{resourceType, unique})
+
+	if g.imports && id != "" {
+		body.AppendNewline()
+		importBody := body.AppendNewBlock("import", nil).Body()
+		importBody.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: resourceType},
+			hcl.TraverseAttr{Name: unique},
+		})
+		importBody.SetAttributeValue("id", cty.StringVal(id))
+	}
+
+	return unique, block.Body()
+}
+
+// reference sets an attribute to the name of a generated resource if there is
+// one, or to the name itself otherwise.
+func (g *hclGenerator) reference(body *hclwrite.Body, attribute string, resourceType string, vhost string, name string) {
+	if label, ok := g.generated[resourceType+"\x00"+vhost+"\x00"+name]; ok {
+		body.SetAttributeTraversal(attribute, hcl.Traversal{
+			hcl.TraverseRoot{Name: resourceType},
+			hcl.TraverseAttr{Name: label},
+			hcl.TraverseAttr{Name: "name"},
+		})
+		return
+	}
+
+	body.SetAttributeValue(attribute, cty.StringVal(name))
+}
+
+func (g *hclGenerator) register(resourceType string, vhost string, name string, label string) {
+	g.generated[resourceType+"\x00"+vhost+"\x00"+name] = label
+}
+
+// vhostLabel is the part of the labels naming the vhost of an object.
+func vhostLabel(vhost string) string {
+	if vhost == "/" {
+		return "default"
+	}
+	return vhost
+}
+
+func (g *hclGenerator) vhost(name string) {
+	label, body := g.resource("rabbitmq_vhost", name, vhostLabel(name))
+	body.SetAttributeValue("name", cty.StringVal(name))
+
+	g.register("rabbitmq_vhost", "", name, label)
+}
+
+func (g *hclGenerator) user(user rabbithole.UserInfo) {
+	label, body := g.resource("rabbitmq_user", user.Name, user.Name)
+	body.SetAttributeValue("name", cty.StringVal(user.Name))
+
+	// The hash keeps the current password, without it the user would be
+	// updated without password on the next change.
+	if user.PasswordHash != "" {
+		body.SetAttributeValue("password_hash", cty.StringVal(user.PasswordHash))
+		body.SetAttributeValue("hashing_algorithm", cty.StringVal(user.HashingAlgorithm.String()))
+	}
+
+	var tags []cty.Value
+	customTags := false
+	for _, tag := range user.Tags {
+		if tag != "" {
+			tags = append(tags, cty.StringVal(tag))
+			customTags = customTags || !isKnownUserTag(tag)
+		}
+	}
+	if len(tags) > 0 {
+		body.SetAttributeValue("tags", cty.ListVal(tags))
+	}
+	if customTags {
+		body.SetAttributeValue("allow_custom_tags", cty.True)
+	}
+
+	g.register("rabbitmq_user", "", user.Name, label)
+}
+
+func (g *hclGenerator) permissions(permission rabbithole.PermissionInfo) {
+	id := fmt.Sprintf("%s@%s", permission.User, permission.Vhost)
+	_, body := g.resource("rabbitmq_permissions", id, permission.User, vhostLabel(permission.Vhost))
+	g.reference(body, "user", "rabbitmq_user", "", permission.User)
+	g.reference(body, "vhost", "rabbitmq_vhost", "", permission.Vhost)
+
+	permissions := body.AppendNewBlock("permissions", nil).Body()
+	permissions.SetAttributeValue("configure", cty.StringVal(permission.Configure))
+	permissions.SetAttributeValue("write", cty.StringVal(permission.Write))
+	permissions.SetAttributeValue("read", cty.StringVal(permission.Read))
+}
+
+func (g *hclGenerator) topicPermission(permission rabbithole.TopicPermissionInfo) {
+	id := topicPermissionId(permission.User, permission.Vhost, permission.Exchange)
+	_, body := g.resource("rabbitmq_topic_permission", id, permission.User, vhostLabel(permission.Vhost), permission.Exchange)
+	g.reference(body, "user", "rabbitmq_user", "", permission.User)
+	g.reference(body, "vhost", "rabbitmq_vhost", "", permission.Vhost)
+	g.reference(body, "exchange", "rabbitmq_exchange", permission.Vhost, permission.Exchange)
+	body.SetAttributeValue("write", cty.StringVal(permission.Write))
+	body.SetAttributeValue("read", cty.StringVal(permission.Read))
+}
+
+// exchange appends the resource of an exchange, and returns false for the
+// exchanges declared by RabbitMQ, which are left out.
+func (g *hclGenerator) exchange(exchange rabbithole.ExchangeInfo) bool {
+	// The default and amq.* exchanges are declared by RabbitMQ
+	if exchange.Name == "" || strings.HasPrefix(exchange.Name, "amq.") {
+		return false
+	}
+
+	id := fmt.Sprintf("%s@%s", exchange.Name, exchange.Vhost)
+	label, body := g.resource("rabbitmq_exchange", id, vhostLabel(exchange.Vhost), exchange.Name)
+	body.SetAttributeValue("name", cty.StringVal(exchange.Name))
+	g.reference(body, "vhost", "rabbitmq_vhost", "", exchange.Vhost)
+
+	settings := body.AppendNewBlock("settings", nil).Body()
+	settings.SetAttributeValue("type", cty.StringVal(exchange.Type))
+	settings.SetAttributeValue("durable", cty.BoolVal(exchange.Durable))
+	settings.SetAttributeValue("auto_delete", cty.BoolVal(bool(exchange.AutoDelete)))
+	if nonStringInArguments(exchange.Arguments) {
+		bytes, _ := json.Marshal(exchange.Arguments)
+		settings.SetAttributeValue("arguments_json", cty.StringVal(string(bytes)))
+	} else if len(exchange.Arguments) > 0 {
+		arguments := make(map[string]cty.Value)
+		for key, value := range exchange.Arguments {
+			arguments[key] = cty.StringVal(formatValue(value))
+		}
+		settings.SetAttributeValue("arguments", cty.MapVal(arguments))
+	}
+
+	g.register("rabbitmq_exchange", exchange.Vhost, exchange.Name, label)
+	return true
+}
+
+// queue appends the resource of a queue, and returns false for exclusive
+// queues, which are left out.
+func (g *hclGenerator) queue(queue rabbithole.QueueInfo) bool {
+	// Exclusive queues belong to the connection which declared them
+	if queue.Exclusive {
+		return false
+	}
+
+	id := fmt.Sprintf("%s@%s", queue.Name, queue.Vhost)
+	label, body := g.resource("rabbitmq_queue", id, vhostLabel(queue.Vhost), queue.Name)
+	body.SetAttributeValue("name", cty.StringVal(queue.Name))
+	g.reference(body, "vhost", "rabbitmq_vhost", "", queue.Vhost)
+
+	settings := body.AppendNewBlock("settings", nil).Body()
+	settings.SetAttributeValue("durable", cty.BoolVal(queue.Durable))
+	settings.SetAttributeValue("auto_delete", cty.BoolVal(bool(queue.AutoDelete)))
+	if len(queue.Arguments) > 0 {
+		bytes, _ := json.Marshal(queue.Arguments)
+		settings.SetAttributeValue("arguments_json", cty.StringVal(string(bytes)))
+	}
+
+	g.register("rabbitmq_queue", queue.Vhost, queue.Name, label)
+	return true
+}
+
+// binding appends the resource of a binding, and returns false for the
+// bindings of the default exchange, which are left out.
+func (g *hclGenerator) binding(binding rabbithole.BindingInfo) bool {
+	// Every queue is implicitly bound to the default exchange
+	if binding.Source == "" {
+		return false
+	}
+
+	_, body := g.resource("rabbitmq_binding", bindingId(binding.Vhost, binding), vhostLabel(binding.Vhost), binding.Source, binding.Destination)
+	g.reference(body, "source", "rabbitmq_exchange", binding.Vhost, binding.Source)
+	g.reference(body, "vhost", "rabbitmq_vhost", "", binding.Vhost)
+	if binding.DestinationType == "queue" {
+		g.reference(body, "destination", "rabbitmq_queue", binding.Vhost, binding.Destination)
+	} else {
+		g.reference(body, "destination", "rabbitmq_exchange", binding.Vhost, binding.Destination)
+	}
+	body.SetAttributeValue("destination_type", cty.StringVal(binding.DestinationType))
+	body.SetAttributeValue("routing_key", cty.StringVal(binding.RoutingKey))
+	if len(binding.Arguments) > 0 {
+		bytes, _ := json.Marshal(binding.Arguments)
+		body.SetAttributeValue("arguments_json", cty.StringVal(string(bytes)))
+	}
+	return true
+}
+
+func (g *hclGenerator) policy(policy rabbithole.Policy) bool {
+	// The policies holding the arguments of queues belong to rabbitmq_queue
+	if strings.HasPrefix(policy.Name, queueArgumentsPolicyName("")) {
+		return false
+	}
+
+	id := fmt.Sprintf("%s@%s", policy.Name, policy.Vhost)
+	_, body := g.resource("rabbitmq_policy", id, vhostLabel(policy.Vhost), policy.Name)
+	body.SetAttributeValue("name", cty.StringVal(policy.Name))
+	g.reference(body, "vhost", "rabbitmq_vhost", "", policy.Vhost)
+
+	setPolicyBlock(body, policy.Pattern, policy.Priority, policy.ApplyTo, policy.Definition)
+
+	return true
+}
+
+func (g *hclGenerator) operatorPolicy(policy rabbithole.OperatorPolicy) {
+	id := fmt.Sprintf("%s@%s", policy.Name, policy.Vhost)
+	_, body := g.resource("rabbitmq_operator_policy", id, vhostLabel(policy.Vhost), policy.Name)
+	body.SetAttributeValue("name", cty.StringVal(policy.Name))
+	g.reference(body, "vhost", "rabbitmq_vhost", "", policy.Vhost)
+
+	setPolicyBlock(body, policy.Pattern, policy.Priority, policy.ApplyTo, policy.Definition)
+}
+
+// setPolicyBlock appends the policy block shared by policies and operator
+// policies. The definition is read back as strings, see ReadPolicy.
+func setPolicyBlock(body *hclwrite.Body, pattern string, priority int, applyTo string, policyDefinition rabbithole.PolicyDefinition) {
+	block := body.AppendNewBlock("policy", nil).Body()
+	block.SetAttributeValue("pattern", cty.StringVal(pattern))
+	block.SetAttributeValue("priority", cty.NumberIntVal(int64(priority)))
+	block.SetAttributeValue("apply_to", cty.StringVal(applyTo))
+
+	definition := make(map[string]cty.Value)
+	for key, value := range policyDefinition {
+		if v, ok := value.([]interface{}); ok {
+			var nodes []string
+			for _, node := range v {
+				if n, ok := node.(string); ok {
+					nodes = append(nodes, n)
+				}
+			}
+			value = strings.Join(nodes, ",")
+		}
+		definition[key] = cty.StringVal(formatValue(value))
+	}
+	if len(definition) > 0 {
+		block.SetAttributeValue("definition", cty.MapVal(definition))
+	} else {
+		block.SetAttributeValue("definition", cty.MapValEmpty(cty.String))
+	}
+}
+
+// vhostLimits appends the resource of the limits of a vhost, and returns
+// false when some of them have no attribute in rabbitmq_vhost_limits.
+func (g *hclGenerator) vhostLimits(limits rabbithole.VhostLimitsInfo) bool {
+	attributes := make(map[string]string, len(vhostLimits.attributes))
+	for attribute, limit := range vhostLimits.attributes {
+		attributes[limit] = attribute
+	}
+	for limit := range limits.Value {
+		if _, ok := attributes[limit]; !ok {
+			return false
+		}
+	}
+
+	_, body := g.resource("rabbitmq_vhost_limits", limits.Vhost, vhostLabel(limits.Vhost))
+	g.reference(body, "vhost", "rabbitmq_vhost", "", limits.Vhost)
+
+	var sorted []string
+	for limit := range limits.Value {
+		sorted = append(sorted, limit)
+	}
+	sort.Strings(sorted)
+	for _, limit := range sorted {
+		body.SetAttributeValue(attributes[limit], cty.NumberIntVal(int64(limits.Value[limit])))
+	}
+
+	return true
+}
+
+func (g *hclGenerator) shovel(shovel rabbithole.ShovelInfo) {
+	id := fmt.Sprintf("%s@%s", shovel.Name, shovel.Vhost)
+	_, body := g.resource("rabbitmq_shovel", id, vhostLabel(shovel.Vhost), shovel.Name)
+	body.SetAttributeValue("name", cty.StringVal(shovel.Name))
+	g.reference(body, "vhost", "rabbitmq_vhost", "", shovel.Vhost)
+
+	definition := shovel.Definition
+	info := body.AppendNewBlock("info", nil).Body()
+
+	setString := func(attribute string, value string) {
+		if value != "" {
+			info.SetAttributeValue(attribute, cty.StringVal(value))
+		}
+	}
+	setInt := func(attribute string, value int) {
+		if value != 0 {
+			info.SetAttributeValue(attribute, cty.NumberIntVal(int64(value)))
+		}
+	}
+	setBool := func(attribute string, value bool) {
+		if value {
+			info.SetAttributeValue(attribute, cty.True)
+		}
+	}
+
+	if len(definition.SourceURI) > 0 {
+		info.SetAttributeValue("source_uri", cty.StringVal(definition.SourceURI[0]))
+	}
+	setString("source_protocol", definition.SourceProtocol)
+	setString("source_queue", definition.SourceQueue)
+	setString("source_exchange", definition.SourceExchange)
+	setString("source_exchange_key", definition.SourceExchangeKey)
+	setString("source_address", definition.SourceAddress)
+	// The deprecated fields are only set by older shovels
+	if definition.SourceDeleteAfter != "" {
+		setString("source_delete_after", string(definition.SourceDeleteAfter))
+	} else {
+		setString("source_delete_after", string(definition.DeleteAfter))
+	}
+	if definition.SourcePrefetchCount != 0 {
+		setInt("source_prefetch_count", definition.SourcePrefetchCount)
+	} else {
+		setInt("source_prefetch_count", definition.PrefetchCount)
+	}
+	if len(definition.DestinationURI) > 0 {
+		info.SetAttributeValue("destination_uri", cty.StringVal(definition.DestinationURI[0]))
+	}
+	setString("destination_protocol", definition.DestinationProtocol)
+	setString("destination_queue", definition.DestinationQueue)
+	setString("destination_exchange", definition.DestinationExchange)
+	setString("destination_exchange_key", definition.DestinationExchangeKey)
+	setString("destination_address", definition.DestinationAddress)
+	setString("destination_application_properties", definition.DestinationApplicationProperties)
+	setString("destination_properties", definition.DestinationProperties)
+	setString("destination_publish_properties", definition.DestinationPublishProperties)
+	setBool("destination_add_forward_headers", definition.DestinationAddForwardHeaders || definition.AddForwardHeaders)
+	setBool("destination_add_timestamp_header", definition.DestinationAddTimestampHeader)
+	setString("ack_mode", definition.AckMode)
+	setInt("reconnect_delay", definition.ReconnectDelay)
+}
+
+func (g *hclGenerator) federationUpstream(upstream rabbithole.FederationUpstream) {
+	id := fmt.Sprintf("%s@%s", upstream.Name, upstream.Vhost)
+	_, body := g.resource("rabbitmq_federation_upstream", id, vhostLabel(upstream.Vhost), upstream.Name)
+	body.SetAttributeValue("name", cty.StringVal(upstream.Name))
+	g.reference(body, "vhost", "rabbitmq_vhost", "", upstream.Vhost)
+
+	definition := upstream.Definition
+	block := body.AppendNewBlock("definition", nil).Body()
+	if len(definition.Uri) > 0 {
+		block.SetAttributeValue("uri", cty.StringVal(definition.Uri[0]))
+	}
+
+	for _, v := range []struct {
+		attribute string
+		value     int
+	}{
+		{"prefetch_count", definition.PrefetchCount},
+		{"reconnect_delay", definition.ReconnectDelay},
+		{"max_hops", definition.MaxHops},
+		{"expires", definition.Expires},
+		{"message_ttl", int(definition.MessageTTL)},
+	} {
+		if v.value != 0 {
+			block.SetAttributeValue(v.attribute, cty.NumberIntVal(int64(v.value)))
+		}
+	}
+
+	if definition.AckMode != "" {
+		block.SetAttributeValue("ack_mode", cty.StringVal(definition.AckMode))
+	}
+	if definition.TrustUserId {
+		block.SetAttributeValue("trust_user_id", cty.True)
+	}
+	if definition.Exchange != "" {
+		block.SetAttributeValue("exchange", cty.StringVal(definition.Exchange))
+	}
+	if definition.Queue != "" {
+		block.SetAttributeValue("queue", cty.StringVal(definition.Queue))
+	}
+}
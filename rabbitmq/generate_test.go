@@ -0,0 +1,87 @@
+package rabbitmq
+
+import (
+	"strings"
+	"testing"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+)
+
+func TestGeneratorLabels(t *testing.T) {
+	g := newHCLGenerator(false)
+
+	var tests = []struct {
+		names []string
+		label string
+	}{
+		{[]string{"default", "orders"}, "default_orders"},
+		{[]string{"default", "Orders.EU"}, "default_orders_eu"},
+		{[]string{"default", "orders"}, "default_orders_2"},
+		{[]string{"default", "orders"}, "default_orders_3"},
+		{[]string{"1st"}, "_1st"},
+		{[]string{"/"}, "_"},
+	}
+
+	for _, test := range tests {
+		label, _ := g.resource("rabbitmq_queue", "", test.names...)
+		if label != test.label {
+			t.Errorf("Expected label %q for %v, got %q", test.label, test.names, label)
+		}
+	}
+
+	// Labels are unique per resource type
+	if label, _ := g.resource("rabbitmq_exchange", "", "default", "orders"); label != "default_orders" {
+		t.Errorf("Expected label %q, got %q", "default_orders", label)
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	g := newHCLGenerator(true)
+
+	g.vhost("/")
+	g.exchange(rabbithole.ExchangeInfo{Name: "amq.direct", Vhost: "/", Type: "direct", Durable: true})
+	g.exchange(rabbithole.ExchangeInfo{Name: "events", Vhost: "/", Type: "topic", Durable: true})
+	g.exchange(rabbithole.ExchangeInfo{Name: "delayed", Vhost: "/", Type: "x-delayed-message", Arguments: map[string]interface{}{"x-delayed-type": "direct"}})
+	g.exchange(rabbithole.ExchangeInfo{Name: "sharded", Vhost: "/", Type: "x-modulus-hash", Arguments: map[string]interface{}{"x-shards": float64(1000000)}})
+	g.topicPermission(rabbithole.TopicPermissionInfo{User: "guest", Vhost: "/", Exchange: "events", Write: "^orders", Read: ".*"})
+	g.queue(rabbithole.QueueInfo{Name: "orders", Vhost: "/", Durable: true, Arguments: map[string]interface{}{"x-queue-type": "quorum"}})
+	g.queue(rabbithole.QueueInfo{Name: "amq.gen-1", Vhost: "/", Exclusive: true})
+	g.binding(rabbithole.BindingInfo{Vhost: "/", Destination: "orders", DestinationType: "queue", RoutingKey: "orders", PropertiesKey: "orders"})
+	g.binding(rabbithole.BindingInfo{Source: "events", Vhost: "/", Destination: "orders", DestinationType: "queue", RoutingKey: "orders.#", PropertiesKey: "orders.%23"})
+	g.binding(rabbithole.BindingInfo{Source: "events", Vhost: "/", Destination: "audit", DestinationType: "queue", RoutingKey: "#", PropertiesKey: "%23"})
+	g.user(rabbithole.UserInfo{Name: "monitor", Tags: rabbithole.UserTags{"monitoring", "audit"}})
+	g.policy(rabbithole.Policy{Name: "queue-arguments-orders", Vhost: "/", Pattern: "^orders$", ApplyTo: "queues", Priority: 1000})
+	g.policy(rabbithole.Policy{Name: "ttl", Vhost: "/", Pattern: ".*", ApplyTo: "queues", Definition: map[string]interface{}{"message-ttl": float64(86400000)}})
+
+	hcl := string(g.bytes())
+
+	for _, expected := range []string{
+		`resource "rabbitmq_vhost" "default" {`,
+		`id = "/"`,
+		`resource "rabbitmq_exchange" "default_events" {`,
+		`vhost = rabbitmq_vhost.default.name`,
+		`to = rabbitmq_queue.default_orders`,
+		`id = "orders@/"`,
+		`arguments_json = "{\"x-queue-type\":\"quorum\"}"`,
+		`source           = rabbitmq_exchange.default_events.name`,
+		`destination      = rabbitmq_queue.default_orders.name`,
+		`id = "%2F/events/orders/queue/orders.#"`,
+		`destination      = "audit"`,
+		`x-delayed-type = "direct"`,
+		`arguments_json = "{\"x-shards\":1000000}"`,
+		`resource "rabbitmq_topic_permission" "guest_default_events" {`,
+		`exchange = rabbitmq_exchange.default_events.name`,
+		`message-ttl = "86400000"`,
+		`allow_custom_tags = true`,
+	} {
+		if !strings.Contains(hcl, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, hcl)
+		}
+	}
+
+	for _, unexpected := range []string{"amq.direct", "amq.gen-1", `source           = ""`, "queue-arguments-orders"} {
+		if strings.Contains(hcl, unexpected) {
+			t.Errorf("Unexpected %q in:\n%s", unexpected, hcl)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package rabbitmq
 
 import (
+	"fmt"
 	"testing"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccBinding_importBasic(t *testing.T) {
@@ -32,3 +34,32 @@ func TestAccBinding_importBasic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccBinding_importPropertiesKey(t *testing.T) {
+	resourceName := "rabbitmq_binding.test"
+	var bindingInfo rabbithole.BindingInfo
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBindingCheckDestroy(bindingInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBindingConfig_propertiesKey,
+				Check: testAccBindingCheck(
+					resourceName, &bindingInfo,
+				),
+			},
+
+			{
+				// IDs from previous versions contain the properties key
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fmt.Sprintf("%s/%s/%s/%s/%s", percentEncodeSlashes(bindingInfo.Vhost), bindingInfo.Source, bindingInfo.Destination, bindingInfo.DestinationType, bindingInfo.PropertiesKey), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
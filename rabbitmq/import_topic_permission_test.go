@@ -0,0 +1,34 @@
+package rabbitmq
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTopicPermission_importBasic(t *testing.T) {
+	if os.Getenv("RABBITMQ_VERSION") == "3.6" {
+		t.Skip("Topic permissions were added in RabbitMQ 3.7")
+	}
+
+	resourceName := "rabbitmq_topic_permission.foo"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccTopicPermissionCheckDestroy("mctest", "test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTopicPermissionConfig_basic,
+				Check:  testAccTopicPermissionCheck(resourceName, ".*", ".*"),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
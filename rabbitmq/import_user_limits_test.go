@@ -0,0 +1,34 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUserLimits_importBasic(t *testing.T) {
+	resourceName := "rabbitmq_user_limits.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserLimitsCheckDestroy("mctest"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserLimitsConfig_basic,
+				Check: testAccUserLimitsCheck(resourceName, rabbithole.UserLimitsValues{
+					"max-connections": 10,
+					"max-channels":    20,
+				}),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVhostLimits_importBasic(t *testing.T) {
+	resourceName := "rabbitmq_vhost_limits.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccVhostLimitsCheckDestroy("test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVhostLimitsConfig_basic,
+				Check: testAccVhostLimitsCheck(resourceName, rabbithole.VhostLimitsValues{
+					"max-connections": 10,
+					"max-queues":      20,
+				}),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,218 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// limits describes the limits of vhosts or users, which are managed the same
+// way through the functions of rabbit-hole given here. Limits are declared as
+// attributes so that each of them can be updated in place, a negative value
+// means that the limit is not set.
+type limits struct {
+	// The attribute identifying the vhost or user, also used in messages
+	owner string
+	// Attribute -> limit
+	attributes map[string]string
+
+	exists func(rmqc *rabbithole.Client, owner string) error
+	get    func(rmqc *rabbithole.Client, owner string) (map[string]int, error)
+	put    func(rmqc *rabbithole.Client, owner string, values map[string]int) (*http.Response, error)
+	delete func(rmqc *rabbithole.Client, owner string, limits []string) (*http.Response, error)
+}
+
+var vhostLimits = &limits{
+	owner: "vhost",
+	attributes: map[string]string{
+		"max_connections": "max-connections",
+		"max_queues":      "max-queues",
+	},
+
+	exists: func(rmqc *rabbithole.Client, vhost string) error {
+		_, err := rmqc.GetVhost(vhost)
+		return err
+	},
+	get: func(rmqc *rabbithole.Client, vhost string) (map[string]int, error) {
+		limits, err := rmqc.GetVhostLimits(vhost)
+		if err != nil {
+			return nil, err
+		}
+		for _, limit := range limits {
+			if limit.Vhost == vhost {
+				return limit.Value, nil
+			}
+		}
+		return nil, nil
+	},
+	put: func(rmqc *rabbithole.Client, vhost string, values map[string]int) (*http.Response, error) {
+		return rmqc.PutVhostLimits(vhost, values)
+	},
+	delete: func(rmqc *rabbithole.Client, vhost string, limits []string) (*http.Response, error) {
+		return rmqc.DeleteVhostLimits(vhost, limits)
+	},
+}
+
+var userLimits = &limits{
+	owner: "user",
+	attributes: map[string]string{
+		"max_connections": "max-connections",
+		"max_channels":    "max-channels",
+	},
+
+	exists: func(rmqc *rabbithole.Client, user string) error {
+		_, err := rmqc.GetUser(user)
+		return err
+	},
+	get: func(rmqc *rabbithole.Client, user string) (map[string]int, error) {
+		limits, err := rmqc.GetUserLimits(user)
+		if err != nil {
+			return nil, err
+		}
+		for _, limit := range limits {
+			if limit.User == user {
+				return limit.Value, nil
+			}
+		}
+		return nil, nil
+	},
+	put: func(rmqc *rabbithole.Client, user string, values map[string]int) (*http.Response, error) {
+		return rmqc.PutUserLimits(user, values)
+	},
+	delete: func(rmqc *rabbithole.Client, user string, limits []string) (*http.Response, error) {
+		return rmqc.DeleteUserLimits(user, limits)
+	},
+}
+
+func (l *limits) create(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	owner := d.Get(l.owner).(string)
+
+	if err := l.set(rmqc, owner, d); err != nil {
+		return err
+	}
+
+	d.SetId(owner)
+
+	return l.read(d, meta)
+}
+
+func (l *limits) read(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	owner := d.Id()
+
+	if err := l.exists(rmqc, owner); err != nil {
+		return checkDeleted(d, err)
+	}
+
+	d.Set(l.owner, owner)
+
+	return checkDeleted(d, l.readValues(rmqc, owner, d))
+}
+
+func (l *limits) update(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	if err := l.set(rmqc, d.Id(), d); err != nil {
+		return err
+	}
+
+	return l.read(d, meta)
+}
+
+func (l *limits) remove(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	owner := d.Id()
+
+	var cleared []string
+	for _, limit := range l.attributes {
+		cleared = append(cleared, limit)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete %s limits for %s", l.owner, owner)
+
+	resp, err := l.delete(rmqc, owner, cleared)
+	log.Printf("[DEBUG] RabbitMQ: %s limits delete response: %#v", l.owner, resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		// the vhost or user was automatically deleted
+		return nil
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("Error deleting RabbitMQ %s limits: %s", l.owner, resp.Status)
+	}
+
+	return nil
+}
+
+// readValues sets the limit attributes, to -1 for the limits which are not set.
+func (l *limits) readValues(rmqc *rabbithole.Client, owner string, d *schema.ResourceData) error {
+	values, err := l.get(rmqc, owner)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: %s limits retrieved for %s: %#v", l.owner, owner, values)
+
+	for attribute, limit := range l.attributes {
+		if value, ok := values[limit]; ok {
+			d.Set(attribute, value)
+		} else {
+			d.Set(attribute, -1)
+		}
+	}
+
+	return nil
+}
+
+// set sets the limits with a positive value and clears the other ones.
+func (l *limits) set(rmqc *rabbithole.Client, owner string, d *schema.ResourceData) error {
+	values := make(map[string]int)
+	var cleared []string
+	for attribute, limit := range l.attributes {
+		if value := d.Get(attribute).(int); value >= 0 {
+			values[limit] = value
+		} else {
+			cleared = append(cleared, limit)
+		}
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to set %s limits for %s: %#v", l.owner, owner, values)
+
+	if len(values) > 0 {
+		resp, err := l.put(rmqc, owner, values)
+		log.Printf("[DEBUG] RabbitMQ: %s limits set response: %#v", l.owner, resp)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("Error setting RabbitMQ %s limits: %s", l.owner, resp.Status)
+		}
+	}
+
+	if len(cleared) > 0 {
+		resp, err := l.delete(rmqc, owner, cleared)
+		log.Printf("[DEBUG] RabbitMQ: %s limits delete response: %#v", l.owner, resp)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode >= 400 && resp.StatusCode != 404 {
+			return fmt.Errorf("Error clearing RabbitMQ %s limits: %s", l.owner, resp.Status)
+		}
+	}
+
+	return nil
+}
@@ -8,9 +8,8 @@ import (
 	"net/url"
 	"os"
 
-	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -87,25 +86,58 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("RABBITMQ_PROXY", ""),
 			},
+
+			"on_conflict": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("RABBITMQ_ON_CONFLICT", ""),
+				ValidateFunc: validation.StringInSlice(onConflictValues, false),
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"rabbitmq_binding":             resourceBinding(),
-			"rabbitmq_exchange":            resourceExchange(),
-			"rabbitmq_permissions":         resourcePermissions(),
-			"rabbitmq_topic_permissions":   resourceTopicPermissions(),
-			"rabbitmq_federation_upstream": resourceFederationUpstream(),
-			"rabbitmq_operator_policy":     resourceOperatorPolicy(),
-			"rabbitmq_policy":              resourcePolicy(),
-			"rabbitmq_queue":               resourceQueue(),
-			"rabbitmq_user":                resourceUser(),
-			"rabbitmq_vhost":               resourceVhost(),
-			"rabbitmq_shovel":              resourceShovel(),
+			"rabbitmq_binding":              resourceBinding(),
+			"rabbitmq_exchange":             resourceExchange(),
+			"rabbitmq_definitions":          resourceDefinitions(),
+			"rabbitmq_permissions":          resourcePermissions(),
+			"rabbitmq_topic_permission":     resourceTopicPermission(),
+			"rabbitmq_topic_permissions":    resourceTopicPermissions(),
+			"rabbitmq_feature_flag":         resourceFeatureFlag(),
+			"rabbitmq_federation_upstream":  resourceFederationUpstream(),
+			"rabbitmq_operator_policy":      resourceOperatorPolicy(),
+			"rabbitmq_policy":               resourcePolicy(),
+			"rabbitmq_queue":                resourceQueue(),
+			"rabbitmq_quorum_queue_members": resourceQuorumQueueMembers(),
+			"rabbitmq_user":                 resourceUser(),
+			"rabbitmq_user_limits":          resourceUserLimits(),
+			"rabbitmq_vhost":                resourceVhost(),
+			"rabbitmq_vhost_limits":         resourceVhostLimits(),
+			"rabbitmq_shovel":               resourceShovel(),
+			"rabbitmq_super_stream":         resourceSuperStream(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"rabbitmq_exchange": dataSourcesExchange(),
-			"rabbitmq_user":     dataSourcesUser(),
-			"rabbitmq_vhost":    dataSourcesVhost(),
+			"rabbitmq_bindings":          dataSourcesBindings(),
+			"rabbitmq_channels":          dataSourcesChannels(),
+			"rabbitmq_connections":       dataSourcesConnections(),
+			"rabbitmq_consumers":         dataSourcesConsumers(),
+			"rabbitmq_definitions":       dataSourcesDefinitions(),
+			"rabbitmq_effective_policy":  dataSourcesEffectivePolicy(),
+			"rabbitmq_exchange":          dataSourcesExchange(),
+			"rabbitmq_exchanges":         dataSourcesExchanges(),
+			"rabbitmq_feature_flags":     dataSourcesFeatureFlags(),
+			"rabbitmq_health_check":      dataSourcesHealthCheck(),
+			"rabbitmq_nodes":             dataSourcesNodes(),
+			"rabbitmq_overview":          dataSourcesOverview(),
+			"rabbitmq_password_hash":     dataSourcesPasswordHash(),
+			"rabbitmq_permissions":       dataSourcesPermissions(),
+			"rabbitmq_queue":             dataSourcesQueue(),
+			"rabbitmq_queues":            dataSourcesQueues(),
+			"rabbitmq_topic_permissions": dataSourcesTopicPermissions(),
+			"rabbitmq_user":              dataSourcesUser(),
+			"rabbitmq_user_limits":       dataSourcesUserLimits(),
+			"rabbitmq_users":             dataSourcesUsers(),
+			"rabbitmq_vhost":             dataSourcesVhost(),
+			"rabbitmq_vhost_limits":      dataSourcesVhostLimits(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -170,10 +202,11 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		},
 	}
 
-	rmqc, err := rabbithole.NewTLSClient(endpoint, username, password, transport)
+	m, err := newProviderMeta(endpoint, username, password, transport)
 	if err != nil {
 		return nil, err
 	}
+	m.onConflict = d.Get("on_conflict").(string)
 
-	return rmqc, nil
+	return m, nil
 }
@@ -1,10 +1,13 @@
 package rabbitmq
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
-	"net/url"
+	"reflect"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,10 +19,21 @@ import (
 func resourceBinding() *schema.Resource {
 	return &schema.Resource{
 		Create: CreateBinding,
+		Update: UpdateBinding,
 		Read:   ReadBinding,
 		Delete: DeleteBinding,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importBinding,
+		},
+
+		// The IDs of version 0 contain the properties key
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceBindingV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceBindingStateUpgradeV0,
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -55,31 +69,191 @@ func resourceBinding() *schema.Resource {
 			"routing_key": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 
 			"arguments": {
 				Type:          schema.TypeMap,
 				Optional:      true,
-				ForceNew:      true,
 				ConflictsWith: []string{"arguments_json"},
 			},
 			"arguments_json": {
 				Type:             schema.TypeString,
 				Optional:         true,
-				ForceNew:         true,
 				ValidateFunc:     validation.StringIsJSON,
 				ConflictsWith:    []string{"arguments"},
 				DiffSuppressFunc: structure.SuppressJsonDiff,
 			},
 		},
+
+		CustomizeDiff: customizeBindingDiff,
 	}
 }
 
 func CreateBinding(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
+
+	vhost := d.Get("vhost").(string)
+	bindingInfo, err := bindingInfoFromConfig(d)
+	if err != nil {
+		return err
+	}
+
+	if err := declareBinding(rmqc, vhost, bindingInfo); err != nil {
+		return err
+	}
+
+	d.SetId(bindingId(vhost, bindingInfo))
+
+	return ReadBinding(d, meta)
+}
+
+func ReadBinding(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	binding, err := findBinding(rmqc, d.Id())
+	if err != nil {
+		return err
+	}
+
+	// The binding could not be found,
+	// so consider it deleted and remove from state
+	if binding == nil {
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Found Binding: %#v", binding)
+
+	d.Set("vhost", binding.Vhost)
+	d.Set("source", binding.Source)
+	d.Set("destination", binding.Destination)
+	d.Set("destination_type", binding.DestinationType)
+	d.Set("routing_key", binding.RoutingKey)
+	d.Set("properties_key", binding.PropertiesKey)
+
+	if v, ok := d.Get("arguments_json").(string); ok && v != "" {
+		bytes, err := json.Marshal(binding.Arguments)
+		if err != nil {
+			return fmt.Errorf("could not encode arguments as JSON: %w", err)
+		}
+		d.Set("arguments_json", string(bytes))
+	} else {
+		d.Set("arguments", binding.Arguments)
+	}
+
+	return nil
+}
+
+// UpdateBinding declares the binding with the new routing key or arguments
+// before deleting the previous one, so that messages are routed throughout
+// the change.
+func UpdateBinding(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
 
 	vhost := d.Get("vhost").(string)
+	bindingInfo, err := bindingInfoFromConfig(d)
+	if err != nil {
+		return err
+	}
+
+	previous, err := findBinding(rmqc, d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := declareBinding(rmqc, vhost, bindingInfo); err != nil {
+		return err
+	}
+
+	if previous != nil && !sameBinding(*previous, bindingInfo) {
+		if err := deleteBinding(rmqc, *previous); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(bindingId(vhost, bindingInfo))
+
+	return ReadBinding(d, meta)
+}
+
+func DeleteBinding(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	binding, err := findBinding(rmqc, d.Id())
+	if err != nil {
+		return err
+	}
+
+	if binding == nil {
+		// The binding was already deleted
+		return nil
+	}
+
+	return deleteBinding(rmqc, *binding)
+}
+
+// importBinding accepts the IDs containing the properties key, and replaces
+// them by the current format.
+func importBinding(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	rmqc := meta.(*providerMeta).Client
+
+	binding, err := findBinding(rmqc, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if binding != nil {
+		d.SetId(bindingId(binding.Vhost, *binding))
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceBindingV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"source":           {Type: schema.TypeString, Required: true},
+			"vhost":            {Type: schema.TypeString, Required: true},
+			"destination":      {Type: schema.TypeString, Required: true},
+			"destination_type": {Type: schema.TypeString, Required: true},
+			"properties_key":   {Type: schema.TypeString, Computed: true},
+			"routing_key":      {Type: schema.TypeString, Optional: true},
+			"arguments":        {Type: schema.TypeMap, Optional: true},
+			"arguments_json":   {Type: schema.TypeString, Optional: true},
+		},
+	}
+}
+
+// resourceBindingStateUpgradeV0 replaces the ID containing the properties key
+// by the one of the routing key and the arguments, from the state alone.
+func resourceBindingStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	vhost, _ := rawState["vhost"].(string)
+	bindingInfo := rabbithole.BindingInfo{}
+	bindingInfo.Source, _ = rawState["source"].(string)
+	bindingInfo.Destination, _ = rawState["destination"].(string)
+	bindingInfo.DestinationType, _ = rawState["destination_type"].(string)
+	bindingInfo.RoutingKey, _ = rawState["routing_key"].(string)
+
+	if v, ok := rawState["arguments_json"].(string); ok && v != "" {
+		if err := json.Unmarshal([]byte(v), &bindingInfo.Arguments); err != nil {
+			return nil, fmt.Errorf("could not decode arguments_json: %w", err)
+		}
+	} else if v, ok := rawState["arguments"].(map[string]interface{}); ok {
+		bindingInfo.Arguments = v
+	}
+
+	id := bindingId(vhost, bindingInfo)
+	log.Printf("[DEBUG] RabbitMQ: Upgrading binding ID %v to %s", rawState["id"], id)
+	rawState["id"] = id
+
+	return rawState, nil
+}
+
+func bindingInfoFromConfig(d *schema.ResourceData) (rabbithole.BindingInfo, error) {
 	arguments := d.Get("arguments").(map[string]interface{})
 
 	// If arguments_json is used, unmarshal it into a generic interface
@@ -88,170 +262,176 @@ func CreateBinding(d *schema.ResourceData, meta interface{}) error {
 		var arguments_json map[string]interface{}
 		err := json.Unmarshal([]byte(v), &arguments_json)
 		if err != nil {
-			return err
+			return rabbithole.BindingInfo{}, err
 		}
 
 		arguments = arguments_json
 	}
 
-	bindingInfo := rabbithole.BindingInfo{
+	return rabbithole.BindingInfo{
 		Source:          d.Get("source").(string),
 		Destination:     d.Get("destination").(string),
 		DestinationType: d.Get("destination_type").(string),
 		RoutingKey:      d.Get("routing_key").(string),
 		Arguments:       arguments,
-	}
+	}, nil
+}
+
+func declareBinding(rmqc *rabbithole.Client, vhost string, bindingInfo rabbithole.BindingInfo) error {
+	log.Printf("[DEBUG] RabbitMQ: Attempting to declare binding for: vhost=%s source=%s destination=%s destinationType=%s routingKey=%s",
+		vhost, bindingInfo.Source, bindingInfo.Destination, bindingInfo.DestinationType, bindingInfo.RoutingKey)
 
-	propertiesKey, err := declareBinding(rmqc, vhost, bindingInfo)
+	resp, err := rmqc.DeclareBinding(vhost, bindingInfo)
+	log.Printf("[DEBUG] RabbitMQ: Binding declare response: %#v", resp)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("[DEBUG] RabbitMQ: Binding properties key: %s", propertiesKey)
-	bindingInfo.PropertiesKey = propertiesKey
-	name := fmt.Sprintf("%s/%s/%s/%s/%s", percentEncodeSlashes(vhost), bindingInfo.Source, bindingInfo.Destination, bindingInfo.DestinationType, bindingInfo.PropertiesKey)
-	d.SetId(name)
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("Error declaring RabbitMQ binding: %s", resp.Status)
+	}
 
-	return ReadBinding(d, meta)
+	return nil
 }
 
-func ReadBinding(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+// sameBinding checks if RabbitMQ considers two bindings between the same
+// source and destination to be the same binding. Unlike in binding IDs, the
+// type of the argument values matters.
+func sameBinding(a rabbithole.BindingInfo, b rabbithole.BindingInfo) bool {
+	if a.RoutingKey != b.RoutingKey {
+		return false
+	}
+	if len(a.Arguments) == 0 && len(b.Arguments) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a.Arguments, b.Arguments)
+}
+
+// deleteBinding deletes a binding by the properties key RabbitMQ reports
+// for it.
+func deleteBinding(rmqc *rabbithole.Client, binding rabbithole.BindingInfo) error {
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete binding for: vhost=%s source=%s destination=%s destinationType=%s propertiesKey=%s",
+		binding.Vhost, binding.Source, binding.Destination, binding.DestinationType, binding.PropertiesKey)
+
+	resp, err := rmqc.DeleteBinding(binding.Vhost, binding)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Binding delete response: %#v", resp)
+
+	if resp.StatusCode == 404 {
+		// The binding was already deleted
+		return nil
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("Error deleting RabbitMQ binding: %s", resp.Status)
+	}
 
-	log.Printf("[TRACE] RabbitMQ: read binding resource ID (pre-split): %s", d.Id())
-	bindingId := strings.Split(d.Id(), "/")
+	return nil
+}
+
+// findBinding returns the binding with the given ID, or nil if it doesn't
+// exist. Bindings are matched by routing key and arguments, and the imported
+// IDs from before the current format by the properties key they contain.
+func findBinding(rmqc *rabbithole.Client, id string) (*rabbithole.BindingInfo, error) {
+	log.Printf("[TRACE] RabbitMQ: read binding resource ID (pre-split): %s", id)
+	bindingId := strings.Split(id, "/")
 	log.Printf("[DEBUG] RabbitMQ: binding ID: %#v", bindingId)
 	if len(bindingId) < 5 {
-		return fmt.Errorf("Unable to determine binding ID")
+		return nil, fmt.Errorf("Unable to determine binding ID")
 	}
 
 	vhost := percentDecodeSlashes(bindingId[0])
 	source := bindingId[1]
 	destination := bindingId[2]
 	destinationType := bindingId[3]
-	propertiesKey := bindingId[4]
-	log.Printf("[DEBUG] RabbitMQ: Attempting to find binding for: vhost=%s source=%s destination=%s destinationType=%s propertiesKey=%s",
-		vhost, source, destination, destinationType, propertiesKey)
+	routingKey := percentDecodeSlashes(bindingId[4])
+	argumentsKey := ""
+	if len(bindingId) > 5 {
+		argumentsKey = bindingId[5]
+	}
+	propertiesKey := strings.Join(bindingId[4:], "/")
+	log.Printf("[DEBUG] RabbitMQ: Attempting to find binding for: vhost=%s source=%s destination=%s destinationType=%s routingKey=%s argumentsKey=%s",
+		vhost, source, destination, destinationType, routingKey, argumentsKey)
 
 	var bindings []rabbithole.BindingInfo
 	var err error
 	if destinationType == "queue" {
 		bindings, err = rmqc.ListQueueBindingsBetween(vhost, source, destination)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	} else if destinationType == "exchange" {
 		bindings, err = rmqc.ListExchangeBindingsBetween(vhost, source, destination)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		bindings, err = rmqc.ListBindingsIn(vhost)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Bindings retrieved: %#v", bindings)
-	bindingFound := false
-	for _, binding := range bindings {
+
+	var legacy *rabbithole.BindingInfo
+	for i, binding := range bindings {
 		log.Printf("[TRACE] RabbitMQ: Assessing binding: %#v", binding)
-		if binding.Source == source && binding.Destination == destination && binding.DestinationType == destinationType && binding.PropertiesKey == propertiesKey {
-			log.Printf("[DEBUG] RabbitMQ: Found Binding: %#v", binding)
-			bindingFound = true
-
-			d.Set("vhost", binding.Vhost)
-			d.Set("source", binding.Source)
-			d.Set("destination", binding.Destination)
-			d.Set("destination_type", binding.DestinationType)
-			d.Set("routing_key", binding.RoutingKey)
-			d.Set("properties_key", binding.PropertiesKey)
-
-			if v, ok := d.Get("arguments_json").(string); ok && v != "" {
-				bytes, err := json.Marshal(binding.Arguments)
-				if err != nil {
-					return fmt.Errorf("could not encode arguments as JSON: %w", err)
-				}
-				d.Set("arguments_json", string(bytes))
-			} else {
-				d.Set("arguments", binding.Arguments)
-			}
+		if binding.Source != source || binding.Destination != destination || binding.DestinationType != destinationType {
+			continue
 		}
-	}
 
-	// The binding could not be found,
-	// so consider it deleted and remove from state
-	if !bindingFound {
-		d.SetId("")
+		if len(bindingId) <= 6 && binding.RoutingKey == routingKey && bindingArgumentsKey(binding.Arguments) == argumentsKey {
+			return &bindings[i], nil
+		}
+		if binding.PropertiesKey == propertiesKey {
+			legacy = &bindings[i]
+		}
 	}
 
-	return nil
+	return legacy, nil
 }
 
-func DeleteBinding(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
-
-	bindingId := strings.Split(d.Id(), "/")
-	if len(bindingId) < 5 {
-		return fmt.Errorf("Unable to determine binding ID")
+// bindingArgumentsKey identifies the arguments of a binding regardless of
+// their order. Values keep their type, since RabbitMQ tells apart bindings
+// whose arguments only differ by it. It is empty without arguments.
+func bindingArgumentsKey(arguments map[string]interface{}) string {
+	if len(arguments) == 0 {
+		return ""
 	}
 
-	vhost := percentDecodeSlashes(bindingId[0])
-	source := bindingId[1]
-	destination := bindingId[2]
-	destinationType := bindingId[3]
-	propertiesKey := bindingId[4]
+	// Map keys are sorted when encoded
+	bytes, _ := json.Marshal(arguments)
+	sum := sha256.Sum256(bytes)
 
-	bindingInfo := rabbithole.BindingInfo{
-		Vhost:           vhost,
-		Source:          source,
-		Destination:     destination,
-		DestinationType: destinationType,
-		PropertiesKey:   propertiesKey,
-	}
-
-	log.Printf("[DEBUG] RabbitMQ: Attempting to delete binding for: vhost=%s source=%s destination=%s destinationType=%s propertiesKey=%s",
-		vhost, source, destination, destinationType, propertiesKey)
+	return hex.EncodeToString(sum[:8])
+}
 
-	resp, err := rmqc.DeleteBinding(vhost, bindingInfo)
-	if err != nil {
-		return err
+// bindingId returns the id of a binding, which is also used to import it. It
+// is composed of the routing key and a key of the arguments, instead of the
+// properties key computed by RabbitMQ which depends on the order of the
+// arguments.
+func bindingId(vhost string, bindingInfo rabbithole.BindingInfo) string {
+	id := fmt.Sprintf("%s/%s/%s/%s/%s", percentEncodeSlashes(vhost), bindingInfo.Source, bindingInfo.Destination, bindingInfo.DestinationType, percentEncodeSlashes(bindingInfo.RoutingKey))
+	if argumentsKey := bindingArgumentsKey(bindingInfo.Arguments); argumentsKey != "" {
+		id += "/" + argumentsKey
 	}
+	return id
+}
 
-	log.Printf("[DEBUG] RabbitMQ: Binding delete response: %#v", resp)
-
-	if resp.StatusCode == 404 {
-		// The binding was already deleted
+// customizeBindingDiff plans a new properties key when the routing key or the
+// arguments change.
+func customizeBindingDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
 		return nil
 	}
 
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ binding: %s", resp.Status)
+	if d.HasChange("routing_key") || d.HasChange("arguments") || d.HasChange("arguments_json") {
+		return d.SetNewComputed("properties_key")
 	}
 
 	return nil
 }
-
-func declareBinding(rmqc *rabbithole.Client, vhost string, bindingInfo rabbithole.BindingInfo) (string, error) {
-	log.Printf("[DEBUG] RabbitMQ: Attempting to declare binding for: vhost=%s source=%s destination=%s destinationType=%s",
-		vhost, bindingInfo.Source, bindingInfo.Destination, bindingInfo.DestinationType)
-
-	resp, err := rmqc.DeclareBinding(vhost, bindingInfo)
-	log.Printf("[DEBUG] RabbitMQ: Binding declare response: %#v", resp)
-	if err != nil {
-		return "", err
-	}
-
-	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("Error declaring RabbitMQ binding: %s", resp.Status)
-	}
-
-	location := strings.Split(resp.Header.Get("Location"), "/")
-	propertiesKey, err := url.PathUnescape(location[len(location)-1])
-
-	if err != nil {
-		return "", err
-	}
-
-	return propertiesKey, nil
-}
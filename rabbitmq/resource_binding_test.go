@@ -1,6 +1,7 @@
 package rabbitmq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -89,6 +90,115 @@ func TestAccBinding_propertiesKey(t *testing.T) {
 	})
 }
 
+func TestAccBinding_update(t *testing.T) {
+	var bindingInfo rabbithole.BindingInfo
+	var previous rabbithole.BindingInfo
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBindingCheckDestroy(bindingInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBindingConfig_headers("a", `{"x-match": "all", "foo": "bar", "baz": 1}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccBindingCheck("rabbitmq_binding.test", &previous),
+					resource.TestCheckResourceAttr("rabbitmq_binding.test", "routing_key", "a"),
+				),
+			},
+			{
+				// The arguments are the same in another order
+				Config:   testAccBindingConfig_headers("a", `{"baz": 1, "foo": "bar", "x-match": "all"}`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccBindingConfig_headers("b", `{"x-match": "all", "foo": "bar", "baz": 1}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccBindingCheck("rabbitmq_binding.test", &bindingInfo),
+					resource.TestCheckResourceAttr("rabbitmq_binding.test", "routing_key", "b"),
+					func(s *terraform.State) error {
+						return testAccBindingCheckDestroy(previous)(s)
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestBindingId(t *testing.T) {
+	binding := rabbithole.BindingInfo{
+		Source:          "events",
+		Destination:     "orders",
+		DestinationType: "queue",
+		RoutingKey:      "orders/#",
+	}
+	if id := bindingId("/", binding); id != "%2F/events/orders/queue/orders%2F#" {
+		t.Errorf("Unexpected binding id without arguments: %s", id)
+	}
+
+	// Arguments are identified regardless of their order
+	binding.Arguments = map[string]interface{}{"x-match": "all", "count": "1"}
+	configured := bindingId("/", binding)
+	binding.Arguments = map[string]interface{}{"count": "1", "x-match": "all"}
+	if id := bindingId("/", binding); id != configured {
+		t.Errorf("Expected binding id %s, got %s", configured, id)
+	}
+	if !strings.HasPrefix(configured, "%2F/events/orders/queue/orders%2F#/") {
+		t.Errorf("Unexpected binding id with arguments: %s", configured)
+	}
+
+	// but not of the type of their values
+	binding.Arguments = map[string]interface{}{"count": float64(1), "x-match": "all"}
+	if id := bindingId("/", binding); id == configured {
+		t.Errorf("Expected a number argument to change the binding id %s", id)
+	}
+
+	binding.Arguments = map[string]interface{}{"count": "2", "x-match": "all"}
+	if id := bindingId("/", binding); id == configured {
+		t.Errorf("Expected different arguments to change the binding id %s", id)
+	}
+}
+
+func TestResourceBindingStateUpgradeV0(t *testing.T) {
+	binding := rabbithole.BindingInfo{
+		Source:          "events",
+		Destination:     "orders",
+		DestinationType: "queue",
+		RoutingKey:      "orders.#",
+		Arguments:       map[string]interface{}{"x-match": "all", "count": float64(1)},
+	}
+
+	var tests = []struct {
+		state    map[string]interface{}
+		expected string
+	}{
+		{
+			map[string]interface{}{
+				"id": "test/events/orders/queue/orders.%23", "vhost": "test", "source": "events", "destination": "orders",
+				"destination_type": "queue", "routing_key": "orders.#", "properties_key": "orders.%23",
+			},
+			"test/events/orders/queue/orders.#",
+		},
+		{
+			map[string]interface{}{
+				"id": "test/events/orders/queue/orders.%23~abc", "vhost": "test", "source": "events", "destination": "orders",
+				"destination_type": "queue", "routing_key": "orders.#", "properties_key": "orders.%23~abc",
+				"arguments_json": `{"x-match": "all", "count": 1}`,
+			},
+			bindingId("test", binding),
+		},
+	}
+
+	for _, test := range tests {
+		upgraded, err := resourceBindingStateUpgradeV0(context.Background(), test.state, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if upgraded["id"] != test.expected {
+			t.Errorf("Expected binding id %s, got %s", test.expected, upgraded["id"])
+		}
+	}
+}
+
 func testAccBindingCheck(rn string, bindingInfo *rabbithole.BindingInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -101,19 +211,15 @@ func testAccBindingCheck(rn string, bindingInfo *rabbithole.BindingInfo) resourc
 			return fmt.Errorf("binding id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
-		bindingParts := strings.Split(rs.Primary.ID, "/")
-
-		bindings, err := rmqc.ListBindingsIn(percentDecodeSlashes(bindingParts[0]))
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		binding, err := findBinding(rmqc, rs.Primary.ID)
 		if err != nil {
-			return fmt.Errorf("Error retrieving exchange: %s", err)
+			return fmt.Errorf("Error retrieving binding: %s", err)
 		}
 
-		for _, binding := range bindings {
-			if binding.Source == bindingParts[1] && binding.Destination == bindingParts[2] && binding.DestinationType == bindingParts[3] && binding.PropertiesKey == bindingParts[4] {
-				*bindingInfo = binding
-				return nil
-			}
+		if binding != nil {
+			*bindingInfo = *binding
+			return nil
 		}
 
 		return fmt.Errorf("Unable to find binding %s", rn)
@@ -148,7 +254,7 @@ func testAccBindingCheckJsonArguments(rn string, bindingInfo *rabbithole.Binding
 
 func testAccBindingCheckDestroy(bindingInfo rabbithole.BindingInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 
 		bindings, err := rmqc.ListBindingsIn(bindingInfo.Vhost)
 		if err != nil {
@@ -381,3 +487,48 @@ resource "rabbitmq_binding" "test" {
     }
 }
 `
+
+func testAccBindingConfig_headers(routingKey string, arguments string) string {
+	return fmt.Sprintf(`
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_exchange" "test" {
+    name = "test"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    settings {
+        type = "headers"
+        durable = false
+        auto_delete = true
+    }
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    settings {
+        durable = true
+        auto_delete = false
+    }
+}
+
+resource "rabbitmq_binding" "test" {
+    source = "${rabbitmq_exchange.test.name}"
+    vhost = "${rabbitmq_vhost.test.name}"
+    destination = "${rabbitmq_queue.test.name}"
+    destination_type = "queue"
+    routing_key = %q
+    arguments_json = %q
+}`, routingKey, arguments)
+}
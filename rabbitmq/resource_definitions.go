@@ -0,0 +1,381 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDefinitions() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateDefinitions,
+		Update: UpdateDefinitions,
+		Read:   ReadDefinitions,
+		Delete: DeleteDefinitions,
+
+		Schema: map[string]*schema.Schema{
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			// Once applied, the state holds the owned objects as exported by
+			// RabbitMQ, which only differs from the configuration on drift.
+			"definitions_json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: definitionsJSONDiffSuppress,
+			},
+
+			"content_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"owned_objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+
+		CustomizeDiff: customizeDefinitionsDiff,
+	}
+}
+
+func CreateDefinitions(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta)
+
+	vhost := d.Get("vhost").(string)
+
+	defs, err := parseDefinitions(d.Get("definitions_json").(string))
+	if err != nil {
+		return err
+	}
+
+	owned, err := defs.ownedObjects(vhost)
+	if err != nil {
+		return err
+	}
+
+	if err := putDefinitions(rmqc, vhost, defs); err != nil {
+		return err
+	}
+
+	hash, err := definitionsHash(defs)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(hash)
+	d.Set("owned_objects", owned)
+
+	return ReadDefinitions(d, meta)
+}
+
+func ReadDefinitions(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta)
+
+	vhost := d.Get("vhost").(string)
+
+	defs, err := parseDefinitions(d.Get("definitions_json").(string))
+	if err != nil {
+		return err
+	}
+
+	exported, err := getDefinitions(rmqc, vhost)
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Definitions retrieved for %q", vhost)
+
+	projected, err := defs.project(exported, vhost)
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(projected)
+	if err != nil {
+		return err
+	}
+
+	hash, err := definitionsHash(projected)
+	if err != nil {
+		return err
+	}
+
+	d.Set("definitions_json", string(bytes))
+	d.Set("content_hash", hash)
+
+	return nil
+}
+
+func UpdateDefinitions(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta)
+
+	vhost := d.Get("vhost").(string)
+
+	defs, err := parseDefinitions(d.Get("definitions_json").(string))
+	if err != nil {
+		return err
+	}
+
+	owned, err := defs.ownedObjects(vhost)
+	if err != nil {
+		return err
+	}
+
+	if err := putDefinitions(rmqc, vhost, defs); err != nil {
+		return err
+	}
+
+	// The objects removed from the definitions are deleted
+	kept := make(map[string]bool)
+	for _, id := range owned {
+		kept[id] = true
+	}
+
+	oldOwned, _ := d.GetChange("owned_objects")
+	var removed []string
+	for _, id := range oldOwned.([]interface{}) {
+		if !kept[id.(string)] {
+			removed = append(removed, id.(string))
+		}
+	}
+
+	if err := deleteDefinitionObjects(rmqc.Client, removed); err != nil {
+		return err
+	}
+
+	d.Set("owned_objects", owned)
+
+	return ReadDefinitions(d, meta)
+}
+
+func DeleteDefinitions(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta)
+
+	var owned []string
+	for _, id := range d.Get("owned_objects").([]interface{}) {
+		owned = append(owned, id.(string))
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete the objects of definitions %s: %v", d.Id(), owned)
+
+	return deleteDefinitionObjects(rmqc.Client, owned)
+}
+
+func putDefinitions(rmqc *providerMeta, vhost string, defs definitions) error {
+	path := "definitions"
+	if vhost != "" {
+		path = "definitions/" + url.PathEscape(vhost)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to import definitions in %q", vhost)
+
+	resp, err := apiRequest(rmqc, "POST", path, defs, nil)
+	log.Printf("[DEBUG] RabbitMQ: Definitions import response: %#v", resp)
+	if err != nil {
+		return fmt.Errorf("Error importing RabbitMQ definitions: %s", err)
+	}
+
+	return nil
+}
+
+// deleteDefinitionObjects deletes objects in the reverse order of their
+// creation, ignoring the ones which are already gone.
+func deleteDefinitionObjects(rmqc *rabbithole.Client, ids []string) error {
+	for i := len(ids) - 1; i >= 0; i-- {
+		kind, keys, err := parseDefinitionObjectId(ids[i])
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG] RabbitMQ: Attempting to delete %s", ids[i])
+
+		var resp *http.Response
+		switch kind {
+		case "vhost":
+			resp, err = rmqc.DeleteVhost(keys[0])
+		case "user":
+			resp, err = rmqc.DeleteUser(keys[0])
+		case "permissions":
+			resp, err = rmqc.ClearPermissionsIn(keys[1], keys[0])
+		case "topic_permission":
+			resp, err = rmqc.DeleteTopicPermissionsIn(keys[1], keys[0], keys[2])
+		case "global_parameter":
+			resp, err = rmqc.DeleteGlobalParameter(keys[0])
+		case "parameter":
+			resp, err = rmqc.DeleteRuntimeParameter(keys[0], keys[1], keys[2])
+		case "policy":
+			resp, err = rmqc.DeletePolicy(keys[0], keys[1])
+		case "operator_policy":
+			resp, err = rmqc.DeleteOperatorPolicy(keys[0], keys[1])
+		case "exchange":
+			resp, err = rmqc.DeleteExchange(keys[0], keys[1])
+		case "queue":
+			resp, err = rmqc.DeleteQueue(keys[0], keys[1])
+		case "binding":
+			resp, err = deleteDefinitionBinding(rmqc, keys)
+		}
+		log.Printf("[DEBUG] RabbitMQ: Delete response: %#v", resp)
+
+		var errorResponse rabbithole.ErrorResponse
+		if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("Error deleting %s: %s", ids[i], err)
+		}
+		if resp != nil && resp.StatusCode >= 400 && resp.StatusCode != 404 {
+			return fmt.Errorf("Error deleting %s: %s", ids[i], resp.Status)
+		}
+	}
+
+	return nil
+}
+
+// deleteDefinitionBinding deletes the binding identified by the keys of its
+// id, which has to be looked up for its properties key.
+func deleteDefinitionBinding(rmqc *rabbithole.Client, keys []string) (*http.Response, error) {
+	vhost, source, destinationType, destination, routingKey, arguments := keys[0], keys[1], keys[2], keys[3], keys[4], keys[5]
+
+	bindings, err := rmqc.ListBindingsIn(vhost)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, binding := range bindings {
+		if binding.Source != source || binding.DestinationType != destinationType ||
+			binding.Destination != destination || binding.RoutingKey != routingKey {
+			continue
+		}
+
+		bindingArguments, err := definitionJSON(map[string]interface{}{"arguments": binding.Arguments}, "arguments")
+		if err != nil {
+			return nil, err
+		}
+		if bindingArguments == arguments {
+			return rmqc.DeleteBinding(vhost, binding)
+		}
+	}
+
+	return nil, nil
+}
+
+// definitionsJSONDiffSuppress compares the objects of two definitions
+// documents regardless of their formatting.
+func definitionsJSONDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	oldDefs, err := parseDefinitions(old)
+	if err != nil {
+		return false
+	}
+	newDefs, err := parseDefinitions(new)
+	if err != nil {
+		return false
+	}
+
+	oldHash, err := definitionsHash(oldDefs)
+	if err != nil {
+		return false
+	}
+	newHash, err := definitionsHash(newDefs)
+	if err != nil {
+		return false
+	}
+
+	return oldHash == newHash
+}
+
+func customizeDefinitionsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("definitions_json") {
+		return nil
+	}
+
+	if !d.NewValueKnown("definitions_json") || !d.NewValueKnown("vhost") {
+		if err := d.SetNewComputed("content_hash"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("owned_objects")
+	}
+
+	defs, err := parseDefinitions(d.Get("definitions_json").(string))
+	if err != nil {
+		return err
+	}
+
+	owned, err := defs.ownedObjects(d.Get("vhost").(string))
+	if err != nil {
+		return err
+	}
+
+	hash, err := definitionsHash(defs)
+	if err != nil {
+		return err
+	}
+
+	if err := checkOwnedObjects(d, meta.(*providerMeta), owned); err != nil {
+		return err
+	}
+
+	if err := d.SetNew("content_hash", hash); err != nil {
+		return err
+	}
+	return d.SetNew("owned_objects", owned)
+}
+
+// checkOwnedObjects refuses to own the users and vhosts which already exist
+// before the definitions are imported, since destroying the resource would
+// delete them, and deleting a vhost deletes all the objects in it.
+func checkOwnedObjects(d *schema.ResourceDiff, rmqc *providerMeta, owned []string) error {
+	oldOwned, _ := d.GetChange("owned_objects")
+	previously := make(map[string]bool)
+	for _, id := range oldOwned.([]interface{}) {
+		previously[id.(string)] = true
+	}
+
+	for _, id := range owned {
+		if previously[id] {
+			continue
+		}
+
+		kind, keys, err := parseDefinitionObjectId(id)
+		if err != nil {
+			return err
+		}
+
+		switch kind {
+		case "vhost":
+			_, err = rmqc.GetVhost(keys[0])
+		case "user":
+			_, err = rmqc.GetUser(keys[0])
+		default:
+			continue
+		}
+
+		var errorResponse rabbithole.ErrorResponse
+		if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("Error checking the RabbitMQ %s %s: %s", kind, keys[0], err)
+		}
+
+		return fmt.Errorf("RabbitMQ %s %s already exists and would be deleted with the definitions, remove it from the document", kind, keys[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDefinitions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDefinitionsCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDefinitionsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDefinitionsCheck("rabbitmq_definitions.test", "orders", "invoices"),
+					resource.TestCheckResourceAttr("rabbitmq_definitions.test", "owned_objects.#", "3"),
+					resource.TestCheckResourceAttr("rabbitmq_definitions.test", "owned_objects.2", "binding:test/amq.direct/queue/orders/orders/{}"),
+				),
+			},
+			{
+				// The deleted queue is detected and created again
+				PreConfig: func() {
+					rmqc := testAccProvider.Meta().(*providerMeta).Client
+					if _, err := rmqc.DeleteQueue("test", "invoices"); err != nil {
+						panic(err)
+					}
+				},
+				Config: testAccDefinitionsConfig_basic,
+				Check:  testAccDefinitionsCheck("rabbitmq_definitions.test", "orders", "invoices"),
+			},
+			{
+				// The queue removed from the definitions is deleted
+				Config: testAccDefinitionsConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDefinitionsCheck("rabbitmq_definitions.test", "orders"),
+					resource.TestCheckResourceAttr("rabbitmq_definitions.test", "owned_objects.#", "2"),
+					testAccDefinitionsCheckQueueDeleted("invoices"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDefinitions_existingVhost(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					rmqc := testAccProvider.Meta().(*providerMeta).Client
+					if _, err := rmqc.PutVhost("existing", rabbithole.VhostSettings{}); err != nil {
+						t.Fatalf("Error creating vhost: %s", err)
+					}
+				},
+				Config:      testAccDefinitionsConfig_existingVhost,
+				ExpectError: regexp.MustCompile("RabbitMQ vhost existing already exists and would be deleted with the definitions"),
+			},
+		},
+	})
+
+	rmqc := testAccProvider.Meta().(*providerMeta).Client
+	if _, err := rmqc.DeleteVhost("existing"); err != nil {
+		t.Errorf("Error deleting vhost: %s", err)
+	}
+}
+
+func testAccDefinitionsCheck(rn string, queues ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("definitions id not set")
+		}
+
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		for _, queue := range queues {
+			if _, err := rmqc.GetQueue("test", queue); err != nil {
+				return fmt.Errorf("Error retrieving queue %s: %s", queue, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccDefinitionsCheckQueueDeleted(queue string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		if _, err := rmqc.GetQueue("test", queue); err == nil {
+			return fmt.Errorf("Queue %s still exists", queue)
+		}
+
+		return nil
+	}
+}
+
+func testAccDefinitionsCheckDestroy(s *terraform.State) error {
+	rmqc := testAccProvider.Meta().(*providerMeta).Client
+
+	queues, err := rmqc.ListQueuesIn("test")
+	if err != nil {
+		// The vhost itself is gone
+		return nil
+	}
+
+	if len(queues) > 0 {
+		return fmt.Errorf("Queues still exist in vhost test: %v", queues)
+	}
+
+	return nil
+}
+
+const testAccDefinitionsConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_definitions" "test" {
+    vhost = rabbitmq_vhost.test.name
+    definitions_json = jsonencode({
+        queues = [
+            {
+                name = "orders"
+                durable = true
+                auto_delete = false
+                arguments = {
+                    "x-queue-type" = "quorum"
+                }
+            },
+            {
+                name = "invoices"
+                durable = true
+                auto_delete = false
+                arguments = {}
+            },
+        ]
+        bindings = [
+            {
+                source = "amq.direct"
+                destination = "orders"
+                destination_type = "queue"
+                routing_key = "orders"
+                arguments = {}
+            },
+        ]
+    })
+}`
+
+const testAccDefinitionsConfig_update = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_definitions" "test" {
+    vhost = rabbitmq_vhost.test.name
+    definitions_json = jsonencode({
+        queues = [
+            {
+                name = "orders"
+                durable = true
+                auto_delete = false
+                arguments = {
+                    "x-queue-type" = "quorum"
+                }
+            },
+        ]
+        bindings = [
+            {
+                source = "amq.direct"
+                destination = "orders"
+                destination_type = "queue"
+                routing_key = "orders"
+                arguments = {}
+            },
+        ]
+    })
+}`
+
+const testAccDefinitionsConfig_existingVhost = `
+resource "rabbitmq_definitions" "test" {
+    definitions_json = jsonencode({
+        vhosts = [
+            {
+                name = "existing"
+            },
+        ]
+    })
+}`
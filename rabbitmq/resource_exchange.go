@@ -1,17 +1,24 @@
 package rabbitmq
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceExchange() *schema.Resource {
 	return &schema.Resource{
 		Create: CreateExchange,
+		Update: UpdateExchange,
 		Read:   ReadExchange,
 		Delete: DeleteExchange,
 		Importer: &schema.ResourceImporter{
@@ -57,18 +64,31 @@ func resourceExchange() *schema.Resource {
 						},
 
 						"arguments": {
-							Type:     schema.TypeMap,
-							Optional: true,
+							Type:          schema.TypeMap,
+							Optional:      true,
+							ConflictsWith: []string{"settings.0.arguments_json"},
+						},
+
+						"arguments_json": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							ConflictsWith:    []string{"settings.0.arguments"},
+							DiffSuppressFunc: structure.SuppressJsonDiff,
 						},
 					},
 				},
 			},
+
+			"on_conflict": onConflictSchema(),
 		},
+
+		CustomizeDiff: customizeExchangeDiff,
 	}
 }
 
 func CreateExchange(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -79,10 +99,35 @@ func CreateExchange(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Unable to parse settings")
 	}
 
-	if err := declareExchange(rmqc, vhost, name, settingsMap); err != nil {
+	var existing *rabbithole.DetailedExchangeInfo
+	action, err := checkConflict(d, meta.(*providerMeta), "adopt", fmt.Sprintf("RabbitMQ exchange %s@%s", name, vhost), func() (err error) {
+		existing, err = rmqc.GetExchange(vhost, name)
+		return
+	})
+	if err != nil {
 		return err
 	}
 
+	// Declaring an exchange which exists with other properties fails, it is
+	// deleted first to be overwritten.
+	if action == "overwrite" && len(exchangeConflicts(existing, settingsMap)) > 0 {
+		log.Printf("[DEBUG] RabbitMQ: Attempting to delete existing exchange %s@%s", name, vhost)
+
+		resp, err := rmqc.DeleteExchange(vhost, name)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("Error deleting existing RabbitMQ exchange: %s", resp.Status)
+		}
+	}
+
+	if action != "adopt" {
+		if err := declareExchange(rmqc, vhost, name, settingsMap); err != nil {
+			return err
+		}
+	}
+
 	id := fmt.Sprintf("%s@%s", name, vhost)
 	d.SetId(id)
 
@@ -90,7 +135,7 @@ func CreateExchange(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadExchange(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -112,15 +157,32 @@ func ReadExchange(d *schema.ResourceData, meta interface{}) error {
 	e["type"] = exchangeSettings.Type
 	e["durable"] = exchangeSettings.Durable
 	e["auto_delete"] = exchangeSettings.AutoDelete
-	e["arguments"] = exchangeSettings.Arguments
+
+	// Like for queues, arguments_json is kept when it was used, and used for
+	// the arguments which are not all strings.
+	if _, ok := d.GetOk("settings.0.arguments_json"); ok || nonStringInArguments(exchangeSettings.Arguments) {
+		bytes, err := json.Marshal(exchangeSettings.Arguments)
+		if err != nil {
+			return err
+		}
+		e["arguments_json"] = string(bytes)
+	} else {
+		e["arguments"] = exchangeSettings.Arguments
+	}
 	exchange[0] = e
 	d.Set("settings", exchange)
 
 	return nil
 }
 
+// UpdateExchange only handles changes of on_conflict, which has no effect
+// once the exchange is created.
+func UpdateExchange(d *schema.ResourceData, meta interface{}) error {
+	return ReadExchange(d, meta)
+}
+
 func DeleteExchange(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -162,9 +224,11 @@ func declareExchange(rmqc *rabbithole.Client, vhost string, name string, setting
 		exchangeSettings.AutoDelete = v
 	}
 
-	if v, ok := settingsMap["arguments"].(map[string]interface{}); ok {
-		exchangeSettings.Arguments = v
+	arguments, err := exchangeArguments(settingsMap)
+	if err != nil {
+		return err
 	}
+	exchangeSettings.Arguments = arguments
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to declare exchange %s@%s: %#v", name, vhost, exchangeSettings)
 
@@ -180,3 +244,94 @@ func declareExchange(rmqc *rabbithole.Client, vhost string, name string, setting
 
 	return nil
 }
+
+func customizeExchangeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return checkExchangeConflict(d, meta)
+	}
+
+	return nil
+}
+
+// checkExchangeConflict reports an existing exchange with the same name at
+// plan time, when on_conflict is error, or when its properties differ from
+// the configured ones with on_conflict set to adopt.
+func checkExchangeConflict(d *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"name", "vhost", "settings", "on_conflict"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	rmqc := meta.(*providerMeta).Client
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+
+	action := onConflict(d, meta.(*providerMeta), "adopt")
+	if action == "overwrite" {
+		return nil
+	}
+
+	exchange, err := rmqc.GetExchange(vhost, name)
+	var errorResponse rabbithole.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error checking for an existing RabbitMQ exchange %s@%s: %s", name, vhost, err)
+	}
+
+	if action == "error" {
+		return conflictError(fmt.Sprintf("RabbitMQ exchange %s@%s", name, vhost))
+	}
+
+	settingsMap, ok := d.Get("settings").([]interface{})[0].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Unable to parse settings")
+	}
+
+	if conflicts := exchangeConflicts(exchange, settingsMap); len(conflicts) > 0 {
+		return fmt.Errorf("RabbitMQ exchange %s@%s already exists with different properties: %s", name, vhost, strings.Join(conflicts, ", "))
+	}
+
+	return nil
+}
+
+// exchangeConflicts describes the properties of an existing exchange which
+// differ from the configured ones.
+func exchangeConflicts(exchange *rabbithole.DetailedExchangeInfo, settingsMap map[string]interface{}) []string {
+	var conflicts []string
+	if exchangeType, _ := settingsMap["type"].(string); exchange.Type != exchangeType {
+		conflicts = append(conflicts, fmt.Sprintf("type is %s instead of %s", exchange.Type, exchangeType))
+	}
+	if durable, _ := settingsMap["durable"].(bool); exchange.Durable != durable {
+		conflicts = append(conflicts, fmt.Sprintf("durable is %t instead of %t", exchange.Durable, durable))
+	}
+	if autoDelete, _ := settingsMap["auto_delete"].(bool); bool(exchange.AutoDelete) != autoDelete {
+		conflicts = append(conflicts, fmt.Sprintf("auto_delete is %t instead of %t", bool(exchange.AutoDelete), autoDelete))
+	}
+	if exchange.Internal {
+		conflicts = append(conflicts, "internal is true instead of false")
+	}
+
+	// arguments_json is validated as JSON by the schema
+	arguments, _ := exchangeArguments(settingsMap)
+	conflicts = append(conflicts, argumentConflicts(exchange.Arguments, arguments)...)
+
+	return conflicts
+}
+
+// exchangeArguments returns the arguments of the settings of an exchange,
+// from either arguments or arguments_json.
+func exchangeArguments(settingsMap map[string]interface{}) (map[string]interface{}, error) {
+	if v, ok := settingsMap["arguments_json"].(string); ok && v != "" {
+		var arguments map[string]interface{}
+		if err := json.Unmarshal([]byte(v), &arguments); err != nil {
+			return nil, err
+		}
+		return arguments, nil
+	}
+
+	arguments, _ := settingsMap["arguments"].(map[string]interface{})
+	return arguments, nil
+}
@@ -2,6 +2,7 @@ package rabbitmq
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -28,6 +29,49 @@ func TestAccExchange(t *testing.T) {
 	})
 }
 
+func TestAccExchange_argumentsJson(t *testing.T) {
+	var exchangeInfo rabbithole.ExchangeInfo
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccExchangeCheckDestroy(&exchangeInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExchangeConfig_argumentsJson,
+				Check: resource.ComposeTestCheckFunc(
+					testAccExchangeCheck("rabbitmq_exchange.test", &exchangeInfo),
+					resource.TestCheckResourceAttr("rabbitmq_exchange.test", "settings.0.arguments_json", `{"x-shards":1000000}`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExchange_conflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					rmqc := testAccProvider.Meta().(*providerMeta).Client
+					settings := rabbithole.ExchangeSettings{Type: "topic", Durable: true}
+					if _, err := rmqc.DeclareExchange("/", "conflict", settings); err != nil {
+						t.Fatalf("Error declaring exchange: %s", err)
+					}
+				},
+				Config:      testAccExchangeConfig_conflict,
+				ExpectError: regexp.MustCompile("already exists with different properties: type is topic instead of fanout, durable is true instead of false"),
+			},
+		},
+	})
+
+	rmqc := testAccProvider.Meta().(*providerMeta).Client
+	if _, err := rmqc.DeleteExchange("/", "conflict"); err != nil {
+		t.Errorf("Error deleting exchange: %s", err)
+	}
+}
+
 func testAccExchangeCheck(rn string, exchangeInfo *rabbithole.ExchangeInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -39,7 +83,7 @@ func testAccExchangeCheck(rn string, exchangeInfo *rabbithole.ExchangeInfo) reso
 			return fmt.Errorf("exchange id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 		exchParts := strings.Split(rs.Primary.ID, "@")
 
 		exchanges, err := rmqc.ListExchangesIn(exchParts[1])
@@ -60,7 +104,7 @@ func testAccExchangeCheck(rn string, exchangeInfo *rabbithole.ExchangeInfo) reso
 
 func testAccExchangeCheckDestroy(exchangeInfo *rabbithole.ExchangeInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 
 		exchanges, err := rmqc.ListExchangesIn(exchangeInfo.Vhost)
 		if err != nil {
@@ -101,3 +145,24 @@ resource "rabbitmq_exchange" "test" {
         auto_delete = true
     }
 }`
+
+const testAccExchangeConfig_conflict = `
+resource "rabbitmq_exchange" "test" {
+    name = "conflict"
+    settings {
+        type = "fanout"
+        durable = false
+    }
+}`
+
+const testAccExchangeConfig_argumentsJson = `
+resource "rabbitmq_exchange" "test" {
+    name = "sharded"
+    settings {
+        type = "direct"
+        durable = false
+        arguments_json = jsonencode({
+            "x-shards" = 1000000
+        })
+    }
+}`
@@ -0,0 +1,134 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceFeatureFlag() *schema.Resource {
+	return &schema.Resource{
+		Create:        CreateFeatureFlag,
+		Read:          ReadFeatureFlag,
+		DeleteContext: DeleteFeatureFlag,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"stability": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateFeatureFlag(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	name := d.Get("name").(string)
+
+	flag, err := getFeatureFlag(rmqc, name)
+	if err != nil {
+		return err
+	}
+
+	if flag == nil {
+		return fmt.Errorf("Error enabling RabbitMQ feature flag %s: no such feature flag", name)
+	}
+
+	if flag.State == rabbithole.StateUnsupported {
+		return fmt.Errorf("Error enabling RabbitMQ feature flag %s: it is not supported by all the nodes of the cluster", name)
+	}
+
+	if flag.State != rabbithole.StateEnabled {
+		log.Printf("[DEBUG] RabbitMQ: Attempting to enable feature flag %s", name)
+
+		resp, err := rmqc.EnableFeatureFlag(name)
+		log.Printf("[DEBUG] RabbitMQ: Feature flag enable response: %#v", resp)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("Error enabling RabbitMQ feature flag %s: %s", name, resp.Status)
+		}
+	}
+
+	d.SetId(name)
+
+	return ReadFeatureFlag(d, meta)
+}
+
+func ReadFeatureFlag(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	name := d.Id()
+
+	flag, err := getFeatureFlag(rmqc, name)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Feature flag retrieved for %s: %#v", name, flag)
+
+	// A flag which is not enabled anymore, e.g. because the cluster was
+	// rebuilt, has to be enabled again.
+	if flag == nil || flag.State != rabbithole.StateEnabled {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", flag.Name)
+	d.Set("state", string(flag.State))
+	d.Set("stability", string(flag.Stability))
+
+	return nil
+}
+
+func DeleteFeatureFlag(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Feature flags cannot be disabled once enabled
+	log.Printf("[DEBUG] RabbitMQ: Leaving feature flag %s enabled", d.Id())
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "RabbitMQ feature flags cannot be disabled",
+			Detail:   fmt.Sprintf("The feature flag %s was removed from the state but is still enabled on the cluster.", d.Id()),
+		},
+	}
+}
+
+// getFeatureFlag returns the feature flag with the given name, or nil when
+// the cluster doesn't know it.
+func getFeatureFlag(rmqc *rabbithole.Client, name string) (*rabbithole.FeatureFlag, error) {
+	flags, err := rmqc.ListFeatureFlags()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, flag := range flags {
+		if flag.Name == name {
+			return &flag, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,86 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccFeatureFlag(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFeatureFlagCheckDestroy("quorum_queue"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFeatureFlagConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFeatureFlagCheck("rabbitmq_feature_flag.test"),
+					resource.TestCheckResourceAttr("rabbitmq_feature_flag.test", "state", "enabled"),
+					resource.TestCheckResourceAttr("data.rabbitmq_feature_flags.enabled", "feature_flags.0.state", "enabled"),
+				),
+			},
+			{
+				ResourceName:      "rabbitmq_feature_flag.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccFeatureFlagCheck(rn string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("feature flag id not set")
+		}
+
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		flag, err := getFeatureFlag(rmqc, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error retrieving feature flags: %s", err)
+		}
+
+		if flag == nil || flag.State != rabbithole.StateEnabled {
+			return fmt.Errorf("Feature flag %s is not enabled", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+// Destroying the resource leaves the feature flag enabled
+func testAccFeatureFlagCheckDestroy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		flag, err := getFeatureFlag(rmqc, name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving feature flags: %s", err)
+		}
+
+		if flag == nil || flag.State != rabbithole.StateEnabled {
+			return fmt.Errorf("Feature flag %s was disabled", name)
+		}
+
+		return nil
+	}
+}
+
+const testAccFeatureFlagConfig_basic = `
+resource "rabbitmq_feature_flag" "test" {
+    name = "quorum_queue"
+}
+
+data "rabbitmq_feature_flags" "enabled" {
+    state = "enabled"
+    depends_on = [rabbitmq_feature_flag.test]
+}`
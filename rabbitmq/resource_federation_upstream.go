@@ -108,12 +108,14 @@ func resourceFederationUpstream() *schema.Resource {
 					},
 				},
 			},
+
+			"on_conflict": onConflictSchema(),
 		},
 	}
 }
 
 func CreateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -124,10 +126,20 @@ func CreateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Unable to parse federation upstream definition")
 	}
 
-	if err := putFederationUpstream(rmqc, vhost, name, defMap); err != nil {
+	action, err := checkConflict(d, meta.(*providerMeta), "overwrite", fmt.Sprintf("RabbitMQ federation upstream %s@%s", name, vhost), func() error {
+		_, err := rmqc.GetFederationUpstream(vhost, name)
+		return err
+	})
+	if err != nil {
 		return err
 	}
 
+	if action != "adopt" {
+		if err := putFederationUpstream(rmqc, vhost, name, defMap); err != nil {
+			return err
+		}
+	}
+
 	id := fmt.Sprintf("%s@%s", name, vhost)
 	d.SetId(id)
 
@@ -135,7 +147,7 @@ func CreateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -177,7 +189,7 @@ func ReadFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 }
 
 func UpdateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -202,7 +214,7 @@ func UpdateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeleteFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -127,7 +127,7 @@ func testAccFederationUpstreamCheck(rn string, upstream *rabbithole.FederationUp
 		name := id[0]
 		vhost := id[1]
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 		upstreams, err := rmqc.ListFederationUpstreamsIn(vhost)
 		if err != nil {
 			return fmt.Errorf("Error retrieving federation upstreams: %s", err)
@@ -146,7 +146,7 @@ func testAccFederationUpstreamCheck(rn string, upstream *rabbithole.FederationUp
 
 func testAccFederationUpstreamCheckDestroy(upstream *rabbithole.FederationUpstream) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 
 		upstreams, err := rmqc.ListFederationUpstreamsIn(upstream.Vhost)
 		if err != nil {
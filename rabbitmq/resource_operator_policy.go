@@ -62,12 +62,14 @@ func resourceOperatorPolicy() *schema.Resource {
 					},
 				},
 			},
+
+			"on_conflict": onConflictSchema(),
 		},
 	}
 }
 
 func CreateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -78,17 +80,29 @@ func CreateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Unable to parse operator policy")
 	}
 
-	if err := putOperatorPolicy(rmqc, vhost, name, operatorPolicyMap); err != nil {
+	// Operator policies were always overwritten before on_conflict, which is
+	// kept as the default
+	action, err := checkConflict(d, meta.(*providerMeta), "overwrite", fmt.Sprintf("RabbitMQ operator policy %s@%s", name, vhost), func() error {
+		_, err := rmqc.GetOperatorPolicy(vhost, name)
+		return err
+	})
+	if err != nil {
 		return err
 	}
 
+	if action != "adopt" {
+		if err := putOperatorPolicy(rmqc, vhost, name, operatorPolicyMap); err != nil {
+			return err
+		}
+	}
+
 	d.SetId(fmt.Sprintf("%s@%s", name, vhost))
 
 	return ReadOperatorPolicy(d, meta)
 }
 
 func ReadOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -136,7 +150,7 @@ func ReadOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 }
 
 func UpdateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -161,7 +175,7 @@ func UpdateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeleteOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -56,12 +56,14 @@ func resourcePermissions() *schema.Resource {
 					},
 				},
 			},
+
+			"on_conflict": onConflictSchema(),
 		},
 	}
 }
 
 func CreatePermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	user := d.Get("user").(string)
 	vhost := d.Get("vhost").(string)
@@ -72,10 +74,22 @@ func CreatePermissions(d *schema.ResourceData, meta interface{}) error {
 		permsMap = permsList[0].(map[string]interface{})
 	}
 
-	if err := setPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
+	// Permissions were always overwritten before on_conflict, which is kept as
+	// the default, e.g. for the default permissions of guest
+	action, err := checkConflict(d, meta.(*providerMeta), "overwrite", fmt.Sprintf("RabbitMQ permissions of user %s in vhost %s", user, vhost), func() error {
+		_, err := rmqc.GetPermissionsIn(vhost, user)
+		return err
+	})
+	if err != nil {
 		return err
 	}
 
+	if action != "adopt" {
+		if err := setPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
+			return err
+		}
+	}
+
 	id := fmt.Sprintf("%s@%s", user, vhost)
 	d.SetId(id)
 
@@ -83,7 +97,7 @@ func CreatePermissions(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -112,7 +126,7 @@ func ReadPermissions(d *schema.ResourceData, meta interface{}) error {
 }
 
 func UpdatePermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -137,7 +151,7 @@ func UpdatePermissions(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeletePermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
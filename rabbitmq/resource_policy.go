@@ -1,14 +1,18 @@
 package rabbitmq
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"strconv"
 	"strings"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePolicy() *schema.Resource {
@@ -18,7 +22,9 @@ func resourcePolicy() *schema.Resource {
 		Read:   ReadPolicy,
 		Delete: DeletePolicy,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStatePassthroughWithDefaults(map[string]interface{}{
+				"max_matches": -1,
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -41,8 +47,9 @@ func resourcePolicy() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"pattern": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validatePolicyPattern,
 						},
 
 						"priority": {
@@ -62,12 +69,29 @@ func resourcePolicy() *schema.Resource {
 					},
 				},
 			},
+
+			"max_matches": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+
+			"matched_objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"on_conflict": onConflictSchema(),
 		},
+
+		CustomizeDiff: customizePolicyDiff,
 	}
 }
 
 func CreatePolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -78,10 +102,20 @@ func CreatePolicy(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Unable to parse policy")
 	}
 
-	if err := putPolicy(rmqc, vhost, name, policyMap); err != nil {
+	action, err := checkConflict(d, meta.(*providerMeta), "error", fmt.Sprintf("RabbitMQ policy %s@%s", name, vhost), func() error {
+		_, err := rmqc.GetPolicy(vhost, name)
+		return err
+	})
+	if err != nil {
 		return err
 	}
 
+	if action != "adopt" {
+		if err := putPolicy(rmqc, vhost, name, policyMap); err != nil {
+			return err
+		}
+	}
+
 	id := fmt.Sprintf("%s@%s", name, vhost)
 	d.SetId(id)
 
@@ -89,7 +123,7 @@ func CreatePolicy(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -137,7 +171,7 @@ func ReadPolicy(d *schema.ResourceData, meta interface{}) error {
 }
 
 func UpdatePolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -162,7 +196,7 @@ func UpdatePolicy(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeletePolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -242,3 +276,105 @@ func putPolicy(rmqc *rabbithole.Client, vhost string, name string, policyMap map
 
 	return nil
 }
+
+// customizePolicyDiff previews the queues and exchanges a new policy, or one
+// whose pattern or apply_to changes, will apply to, and enforces max_matches
+// when it is set. The objects are only listed then, not on every refresh.
+func customizePolicyDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	changed := d.Id() == "" || d.HasChange("policy.0.pattern") || d.HasChange("policy.0.apply_to")
+	maxMatches := d.Get("max_matches").(int)
+	if !changed && (maxMatches < 0 || !d.HasChange("max_matches")) {
+		return nil
+	}
+
+	if !d.NewValueKnown("vhost") || !d.NewValueKnown("policy.0.pattern") || !d.NewValueKnown("policy.0.apply_to") {
+		return d.SetNewComputed("matched_objects")
+	}
+
+	rmqc := meta.(*providerMeta).Client
+
+	vhost := d.Get("vhost").(string)
+	pattern := d.Get("policy.0.pattern").(string)
+	applyTo := d.Get("policy.0.apply_to").(string)
+
+	// RabbitMQ evaluates patterns as PCRE, those which are not supported by Go
+	// regular expressions, such as lookaheads, cannot be previewed. The
+	// pattern is reported by validatePolicyPattern.
+	if _, err := regexp.Compile(pattern); err != nil {
+		if maxMatches >= 0 {
+			return fmt.Errorf("Unable to enforce max_matches, policy pattern %q is not supported by Go regular expressions: %s", pattern, err)
+		}
+		return d.SetNewComputed("matched_objects")
+	}
+
+	matched, err := policyMatchedObjects(rmqc, vhost, pattern, applyTo)
+	if err != nil {
+		return err
+	}
+
+	if maxMatches >= 0 && len(matched) > maxMatches {
+		return fmt.Errorf("Policy pattern %q matches %d objects in vhost %s, more than max_matches (%d): %s",
+			pattern, len(matched), vhost, maxMatches, strings.Join(matched, ", "))
+	}
+
+	return d.SetNew("matched_objects", matched)
+}
+
+// validatePolicyPattern warns about the patterns which are valid PCRE, as
+// RabbitMQ expects, but not Go regular expressions, since the objects they
+// match cannot be previewed.
+func validatePolicyPattern(v interface{}, k string) (ws []string, errors []error) {
+	pattern := v.(string)
+	if _, err := regexp.Compile(pattern); err != nil {
+		ws = append(ws, fmt.Sprintf("%s %q is not supported by Go regular expressions, matched_objects is unknown and max_matches cannot be enforced: %s", k, pattern, err))
+	}
+
+	return
+}
+
+// policyMatchedObjects lists the existing queues and exchanges of a vhost a
+// policy applies to, as "queue:<name>" and "exchange:<name>".
+func policyMatchedObjects(rmqc *rabbithole.Client, vhost string, pattern string, applyTo string) ([]string, error) {
+	matched := []string{}
+
+	queues, err := rmqc.ListQueuesIn(vhost)
+	if err != nil {
+		var errorResponse rabbithole.ErrorResponse
+		if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 {
+			// the vhost doesn't exist yet
+			return matched, nil
+		}
+		return nil, err
+	}
+
+	for _, queue := range queues {
+		matches, err := policyMatches(pattern, applyTo, "queue", queue.Name, queue.Type)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			matched = append(matched, "queue:"+queue.Name)
+		}
+	}
+
+	exchanges, err := rmqc.ListExchangesIn(vhost)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, exchange := range exchanges {
+		// Policies don't apply to the default exchange
+		if exchange.Name == "" {
+			continue
+		}
+		matches, err := policyMatches(pattern, applyTo, "exchange", exchange.Name, "")
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			matched = append(matched, "exchange:"+exchange.Name)
+		}
+	}
+
+	return matched, nil
+}
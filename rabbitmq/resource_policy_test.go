@@ -1,7 +1,11 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -34,6 +38,104 @@ func TestAccPolicy(t *testing.T) {
 	})
 }
 
+func TestAccPolicy_matchedObjects(t *testing.T) {
+	var policy rabbithole.Policy
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPolicyCheckDestroy(&policy),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyConfig_matchedObjects(".*", "queues"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPolicyCheck("rabbitmq_policy.test", &policy),
+					resource.TestCheckResourceAttr("rabbitmq_policy.test", "matched_objects.#", "2"),
+				),
+			},
+			{
+				Config: testAccPolicyConfig_matchedObjects("^orders-1$", "queues"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPolicyCheck("rabbitmq_policy.test", &policy),
+					resource.TestCheckResourceAttr("rabbitmq_policy.test", "matched_objects.#", "1"),
+					resource.TestCheckResourceAttr("rabbitmq_policy.test", "matched_objects.0", "queue:orders-1"),
+				),
+			},
+			{
+				// The amq.* exchanges and the two queues exceed max_matches
+				Config:      testAccPolicyConfig_matchedObjects(".*", "all"),
+				ExpectError: regexp.MustCompile("more than max_matches"),
+			},
+		},
+	})
+}
+
+func TestCustomizePolicyDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/queues/%2F":
+			w.Write([]byte(`[{"name": "orders", "vhost": "/", "type": "classic"}, {"name": "amq.gen-1", "vhost": "/", "type": "classic"}]`))
+		case "/api/exchanges/%2F":
+			w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	meta, err := newProviderMeta(server.URL, "guest", "guest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff := func(pattern string, maxMatches int) (*terraform.InstanceDiff, error) {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":        "test",
+			"vhost":       "/",
+			"max_matches": maxMatches,
+			"policy": []interface{}{map[string]interface{}{
+				"pattern":    pattern,
+				"priority":   0,
+				"apply_to":   "queues",
+				"definition": map[string]interface{}{"max-length": "10"},
+			}},
+		})
+		return resourcePolicy().Diff(context.Background(), nil, config, meta)
+	}
+
+	d, err := diff("^orders$", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attr := d.Attributes["matched_objects.0"]; attr == nil || attr.New != "queue:orders" {
+		t.Errorf("Expected queue:orders to be matched, got %#v", d.Attributes)
+	}
+
+	if _, err := diff(".*", 1); err == nil || !strings.Contains(err.Error(), "more than max_matches") {
+		t.Errorf("Expected max_matches to be enforced, got %v", err)
+	}
+
+	// Lookaheads are valid in RabbitMQ but not in Go, max_matches can't be
+	// enforced and the matched objects are left unknown
+	if _, err := diff(`^(?!amq\.).*`, 0); err == nil || !strings.Contains(err.Error(), "Unable to enforce max_matches") {
+		t.Errorf("Expected max_matches to fail for a lookahead pattern, got %v", err)
+	}
+
+	d, err = diff(`^(?!amq\.).*`, -1)
+	if err != nil {
+		t.Fatalf("Expected no error for a lookahead pattern, got %s", err)
+	}
+	if attr := d.Attributes["matched_objects.#"]; attr == nil || !attr.NewComputed {
+		t.Errorf("Expected matched_objects to be unknown, got %#v", d.Attributes)
+	}
+
+	if ws, errs := validatePolicyPattern(`^(?!amq\.).*`, "pattern"); len(ws) != 1 || len(errs) != 0 {
+		t.Errorf("Expected a warning for a lookahead pattern, got %v, %v", ws, errs)
+	}
+	if ws, errs := validatePolicyPattern("^orders$", "pattern"); len(ws) != 0 || len(errs) != 0 {
+		t.Errorf("Expected no warning, got %v, %v", ws, errs)
+	}
+}
+
 func testAccPolicyCheck(rn string, policy *rabbithole.Policy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -45,7 +147,7 @@ func testAccPolicyCheck(rn string, policy *rabbithole.Policy) resource.TestCheck
 			return fmt.Errorf("policy id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 		policyParts := strings.Split(rs.Primary.ID, "@")
 
 		policies, err := rmqc.ListPolicies()
@@ -66,7 +168,7 @@ func testAccPolicyCheck(rn string, policy *rabbithole.Policy) resource.TestCheck
 
 func testAccPolicyCheckDestroy(policy *rabbithole.Policy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 
 		policies, err := rmqc.ListPolicies()
 		if err != nil {
@@ -140,3 +242,41 @@ resource "rabbitmq_policy" "test" {
         }
     }
 }`
+
+func testAccPolicyConfig_matchedObjects(pattern string, applyTo string) string {
+	return fmt.Sprintf(`
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_queue" "orders_1" {
+    name = "orders-1"
+    vhost = rabbitmq_vhost.test.name
+    settings {
+        durable = true
+    }
+}
+
+resource "rabbitmq_queue" "orders_2" {
+    name = "orders-2"
+    vhost = rabbitmq_vhost.test.name
+    settings {
+        durable = true
+    }
+}
+
+resource "rabbitmq_policy" "test" {
+    name = "test"
+    vhost = rabbitmq_vhost.test.name
+    max_matches = 2
+    policy {
+        pattern = %q
+        priority = 0
+        apply_to = %q
+        definition = {
+            max-length = 1000
+        }
+    }
+    depends_on = [rabbitmq_queue.orders_1, rabbitmq_queue.orders_2]
+}`, pattern, applyTo)
+}
@@ -1,9 +1,14 @@
 package rabbitmq
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
@@ -14,10 +19,13 @@ import (
 func resourceQueue() *schema.Resource {
 	return &schema.Resource{
 		Create: CreateQueue,
+		Update: UpdateQueue,
 		Read:   ReadQueue,
 		Delete: DeleteQueue,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStatePassthroughWithDefaults(map[string]interface{}{
+				"arguments_update_strategy": "replace",
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -34,10 +42,11 @@ func resourceQueue() *schema.Resource {
 				ForceNew: true,
 			},
 
+			// The arguments are replaced or updated in place depending on
+			// arguments_update_strategy, see customizeQueueDiff.
 			"settings": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -59,7 +68,6 @@ func resourceQueue() *schema.Resource {
 							Type:          schema.TypeMap,
 							Optional:      true,
 							ConflictsWith: []string{"settings.0.arguments_json"},
-							ForceNew:      true,
 						},
 
 						"arguments_json": {
@@ -68,17 +76,55 @@ func resourceQueue() *schema.Resource {
 							ValidateFunc:     validation.StringIsJSON,
 							ConflictsWith:    []string{"settings.0.arguments"},
 							DiffSuppressFunc: structure.SuppressJsonDiff,
-							ForceNew:         true,
 						},
 					},
 				},
 			},
+
+			"arguments_update_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "replace",
+				ValidateFunc: validation.StringInSlice([]string{"replace", "policy"}, false),
+			},
+
+			"arguments_policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// The replicas of quorum queues
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"leader": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// Any change of the trigger purges the queue
+			"purge_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"last_purged_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"on_conflict": onConflictSchema(),
 		},
+
+		CustomizeDiff: customizeQueueDiff,
 	}
 }
 
 func CreateQueue(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -89,31 +135,62 @@ func CreateQueue(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Unable to parse settings")
 	}
 
-	// If arguments_json is used, unmarshal it into a generic interface
-	// and use it as the "arguments" key for the queue.
-	if v, ok := settingsMap["arguments_json"].(string); ok && v != "" {
-		var arguments map[string]interface{}
-		err := json.Unmarshal([]byte(v), &arguments)
+	arguments, err := queueArguments(settingsMap)
+	if err != nil {
+		return err
+	}
+
+	// With the policy strategy, the arguments which can be updated are set
+	// by a policy instead of being declared.
+	var policyDefinition map[string]interface{}
+	if d.Get("arguments_update_strategy").(string) == "policy" {
+		arguments, policyDefinition = splitQueueArguments(arguments)
+	}
+
+	delete(settingsMap, "arguments_json")
+	settingsMap["arguments"] = arguments
+
+	var existing *rabbithole.DetailedQueueInfo
+	action, err := checkConflict(d, meta.(*providerMeta), "adopt", fmt.Sprintf("RabbitMQ queue %s@%s", name, vhost), func() (err error) {
+		existing, err = rmqc.GetQueue(vhost, name)
+		return
+	})
+	if err != nil {
+		return err
+	}
+
+	// Declaring a queue which exists with other properties fails, it is
+	// deleted first to be overwritten.
+	if action == "overwrite" && len(queueConflicts(existing, settingsMap, arguments)) > 0 {
+		log.Printf("[DEBUG] RabbitMQ: Attempting to delete existing queue %s@%s", name, vhost)
+
+		resp, err := rmqc.DeleteQueue(vhost, name)
 		if err != nil {
 			return err
 		}
-
-		delete(settingsMap, "arguments_json")
-		settingsMap["arguments"] = arguments
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("Error deleting existing RabbitMQ queue: %s", resp.Status)
+		}
 	}
 
-	if err := declareQueue(rmqc, vhost, name, settingsMap); err != nil {
-		return err
+	if action != "adopt" {
+		if err := declareQueue(rmqc, vhost, name, settingsMap); err != nil {
+			return err
+		}
 	}
 
 	id := fmt.Sprintf("%s@%s", name, vhost)
 	d.SetId(id)
 
+	if err := putQueueArgumentsPolicy(rmqc, vhost, name, policyDefinition); err != nil {
+		return err
+	}
+
 	return ReadQueue(d, meta)
 }
 
 func ReadQueue(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -129,6 +206,20 @@ func ReadQueue(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("name", queueSettings.Name)
 	d.Set("vhost", queueSettings.Vhost)
+	d.Set("members", queueSettings.Members)
+	d.Set("leader", queueSettings.Leader)
+
+	arguments := queueSettings.Arguments
+	if d.Get("arguments_update_strategy").(string) == "policy" {
+		_, useJSON := d.GetOk("settings.0.arguments_json")
+		arguments, err = mergeQueueArgumentsPolicy(rmqc, vhost, name, arguments, !useJSON)
+		if err != nil {
+			return err
+		}
+		d.Set("arguments_policy", queueArgumentsPolicyName(name))
+	} else {
+		d.Set("arguments_policy", "")
+	}
 
 	e := make(map[string]interface{})
 	e["durable"] = queueSettings.Durable
@@ -142,14 +233,14 @@ func ReadQueue(d *schema.ResourceData, meta interface{}) error {
 	// `arguments` cannot receive any values other than a string (d.Set will fail), therefore any drift
 	// containing nonstring values AND the configuration originated from `arguments`,
 	// will now be encoded to `arguments_json`.
-	if _, ok := d.GetOk("settings.0.arguments_json"); ok || nonStringInArguments(queueSettings.Arguments) {
-		bytes, err := json.Marshal(queueSettings.Arguments)
+	if _, ok := d.GetOk("settings.0.arguments_json"); ok || nonStringInArguments(arguments) {
+		bytes, err := json.Marshal(arguments)
 		if err != nil {
 			return err
 		}
 		e["arguments_json"] = string(bytes)
 	} else {
-		e["arguments"] = queueSettings.Arguments
+		e["arguments"] = arguments
 	}
 
 	queue := make([]map[string]interface{}, 1)
@@ -158,8 +249,53 @@ func ReadQueue(d *schema.ResourceData, meta interface{}) error {
 	return d.Set("settings", queue)
 }
 
+func UpdateQueue(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return err
+	}
+
+	// Only the arguments set by the policy can change in place, and it is
+	// removed when switching back to the replace strategy.
+	if d.HasChanges("settings", "arguments_update_strategy") {
+		arguments, err := queueArguments(d.Get("settings").([]interface{})[0].(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+
+		var policyDefinition map[string]interface{}
+		if d.Get("arguments_update_strategy").(string) == "policy" {
+			_, policyDefinition = splitQueueArguments(arguments)
+		}
+
+		if err := putQueueArgumentsPolicy(rmqc, vhost, name, policyDefinition); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("purge_trigger") {
+		log.Printf("[DEBUG] RabbitMQ: Attempting to purge queue for %s", d.Id())
+
+		resp, err := rmqc.PurgeQueue(vhost, name)
+		log.Printf("[DEBUG] RabbitMQ: Queue purge response: %#v", resp)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("Error purging RabbitMQ queue: %s", resp.Status)
+		}
+
+		d.Set("last_purged_at", time.Now().UTC().Format(time.RFC3339))
+	}
+
+	return ReadQueue(d, meta)
+}
+
 func DeleteQueue(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -170,17 +306,19 @@ func DeleteQueue(d *schema.ResourceData, meta interface{}) error {
 
 	resp, err := rmqc.DeleteQueue(vhost, name)
 	log.Printf("[DEBUG] RabbitMQ: Queue delete response: %#v", resp)
-	if err != nil {
+
+	// A 404 means the queue was automatically deleted
+	var errorResponse rabbithole.ErrorResponse
+	if err != nil && !(errors.As(err, &errorResponse) && errorResponse.StatusCode == 404) {
 		return err
 	}
 
-	if resp.StatusCode == 404 {
-		// the queue was automatically deleted
-		return nil
+	if err == nil && resp.StatusCode >= 400 && resp.StatusCode != 404 {
+		return fmt.Errorf("Error deleting RabbitMQ queue: %s", resp.Status)
 	}
 
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ queue: %s", resp.Status)
+	if d.Get("arguments_update_strategy").(string) == "policy" {
+		return putQueueArgumentsPolicy(rmqc, vhost, name, nil)
 	}
 
 	return nil
@@ -227,3 +365,285 @@ func nonStringInArguments(args map[string]interface{}) bool {
 	}
 	return false
 }
+
+func customizeQueueDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return checkQueueConflict(d, meta)
+	}
+
+	// The queue is only purged when the trigger changes after its creation
+	if d.HasChange("purge_trigger") {
+		if err := d.SetNewComputed("last_purged_at"); err != nil {
+			return err
+		}
+	}
+
+	var changed []string
+	for _, key := range []string{"settings.0.arguments", "settings.0.arguments_json", "arguments_update_strategy"} {
+		if d.HasChange(key) {
+			changed = append(changed, key)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if !d.NewValueKnown("settings.0.arguments") || !d.NewValueKnown("settings.0.arguments_json") {
+		return d.ForceNew(changed[0])
+	}
+
+	// The queue is replaced when the arguments it is declared with change
+	declared := func(settings interface{}, strategy interface{}) (map[string]interface{}, error) {
+		settingsList := settings.([]interface{})
+		if len(settingsList) == 0 || settingsList[0] == nil {
+			return nil, nil
+		}
+		arguments, err := queueArguments(settingsList[0].(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		if strategy.(string) == "policy" {
+			arguments, _ = splitQueueArguments(arguments)
+		}
+		return arguments, nil
+	}
+
+	oldSettings, newSettings := d.GetChange("settings")
+	oldStrategy, newStrategy := d.GetChange("arguments_update_strategy")
+	oldArguments, err := declared(oldSettings, oldStrategy)
+	if err != nil {
+		return err
+	}
+	newArguments, err := declared(newSettings, newStrategy)
+	if err != nil {
+		return err
+	}
+
+	if len(oldArguments) != len(newArguments) || !argumentsMatch(oldArguments, newArguments) {
+		return d.ForceNew(changed[0])
+	}
+
+	return nil
+}
+
+// checkQueueConflict reports an existing queue with the same name at plan
+// time, when on_conflict is error, or when its properties differ from the
+// configured ones with on_conflict set to adopt.
+func checkQueueConflict(d *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"name", "vhost", "arguments_update_strategy", "settings.0.arguments", "settings.0.arguments_json", "on_conflict"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	rmqc := meta.(*providerMeta).Client
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+
+	action := onConflict(d, meta.(*providerMeta), "adopt")
+	if action == "overwrite" {
+		return nil
+	}
+
+	queue, err := rmqc.GetQueue(vhost, name)
+	var errorResponse rabbithole.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error checking for an existing RabbitMQ queue %s@%s: %s", name, vhost, err)
+	}
+
+	if action == "error" {
+		return conflictError(fmt.Sprintf("RabbitMQ queue %s@%s", name, vhost))
+	}
+
+	settingsMap, ok := d.Get("settings").([]interface{})[0].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Unable to parse settings")
+	}
+
+	arguments, err := queueArguments(settingsMap)
+	if err != nil {
+		return err
+	}
+	if d.Get("arguments_update_strategy").(string) == "policy" {
+		arguments, _ = splitQueueArguments(arguments)
+	}
+
+	if conflicts := queueConflicts(queue, settingsMap, arguments); len(conflicts) > 0 {
+		return fmt.Errorf("RabbitMQ queue %s@%s already exists with different properties: %s", name, vhost, strings.Join(conflicts, ", "))
+	}
+
+	return nil
+}
+
+// queueConflicts describes the properties of an existing queue which differ
+// from the configured ones, given the arguments the queue is declared with.
+func queueConflicts(queue *rabbithole.DetailedQueueInfo, settingsMap map[string]interface{}, arguments map[string]interface{}) []string {
+	var conflicts []string
+	if durable, _ := settingsMap["durable"].(bool); queue.Durable != durable {
+		conflicts = append(conflicts, fmt.Sprintf("durable is %t instead of %t", queue.Durable, durable))
+	}
+	if autoDelete, _ := settingsMap["auto_delete"].(bool); bool(queue.AutoDelete) != autoDelete {
+		conflicts = append(conflicts, fmt.Sprintf("auto_delete is %t instead of %t", bool(queue.AutoDelete), autoDelete))
+	}
+	if queue.Exclusive {
+		conflicts = append(conflicts, "exclusive is true instead of false")
+	}
+
+	// The type is compared separately since classic queues may be declared
+	// without x-queue-type
+	existingType, configuredType := queue.Type, fmt.Sprint(arguments["x-queue-type"])
+	if existingType == "" {
+		existingType = "classic"
+	}
+	if _, ok := arguments["x-queue-type"]; !ok {
+		configuredType = "classic"
+	}
+	if existingType != configuredType {
+		conflicts = append(conflicts, fmt.Sprintf("type is %s instead of %s", existingType, configuredType))
+	}
+
+	existingArguments := make(map[string]interface{})
+	for key, value := range queue.Arguments {
+		if key != "x-queue-type" {
+			existingArguments[key] = value
+		}
+	}
+	configuredArguments := make(map[string]interface{})
+	for key, value := range arguments {
+		if key != "x-queue-type" {
+			configuredArguments[key] = value
+		}
+	}
+	conflicts = append(conflicts, argumentConflicts(existingArguments, configuredArguments)...)
+
+	return conflicts
+}
+
+// queuePolicyArguments maps the queue arguments which can also be set by a
+// policy to their key in the policy definition.
+var queuePolicyArguments = map[string]string{
+	"x-dead-letter-exchange":          "dead-letter-exchange",
+	"x-dead-letter-routing-key":       "dead-letter-routing-key",
+	"x-dead-letter-strategy":          "dead-letter-strategy",
+	"x-delivery-limit":                "delivery-limit",
+	"x-expires":                       "expires",
+	"x-max-age":                       "max-age",
+	"x-max-length":                    "max-length",
+	"x-max-length-bytes":              "max-length-bytes",
+	"x-message-ttl":                   "message-ttl",
+	"x-overflow":                      "overflow",
+	"x-queue-leader-locator":          "queue-leader-locator",
+	"x-queue-master-locator":          "queue-master-locator",
+	"x-queue-mode":                    "queue-mode",
+	"x-stream-max-segment-size-bytes": "stream-max-segment-size-bytes",
+}
+
+// The generated policy takes precedence over the other policies matching the
+// queue, which only apply one at a time: they no longer apply to the queue at
+// all, only operator policies still do.
+const queueArgumentsPolicyPriority = 1000
+
+func queueArgumentsPolicyName(name string) string {
+	return "queue-arguments-" + name
+}
+
+// queueArguments returns the arguments of the settings of a queue, from
+// either arguments or arguments_json.
+func queueArguments(settingsMap map[string]interface{}) (map[string]interface{}, error) {
+	// If arguments_json is used, unmarshal it into a generic interface
+	// and use it as the "arguments" key for the queue.
+	if v, ok := settingsMap["arguments_json"].(string); ok && v != "" {
+		var arguments map[string]interface{}
+		if err := json.Unmarshal([]byte(v), &arguments); err != nil {
+			return nil, err
+		}
+		return arguments, nil
+	}
+
+	arguments, _ := settingsMap["arguments"].(map[string]interface{})
+	return arguments, nil
+}
+
+// splitQueueArguments separates the arguments declared with the queue from
+// the ones set by its policy, which are returned as a policy definition.
+func splitQueueArguments(arguments map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	declared := make(map[string]interface{})
+	definition := make(map[string]interface{})
+	for key, value := range arguments {
+		if policyKey, ok := queuePolicyArguments[key]; ok {
+			definition[policyKey] = value
+		} else {
+			declared[key] = value
+		}
+	}
+
+	return declared, definition
+}
+
+// putQueueArgumentsPolicy declares the policy of a queue, or deletes it when
+// the definition is empty.
+func putQueueArgumentsPolicy(rmqc *rabbithole.Client, vhost string, name string, definition map[string]interface{}) error {
+	policyName := queueArgumentsPolicyName(name)
+
+	if len(definition) == 0 {
+		log.Printf("[DEBUG] RabbitMQ: Attempting to delete queue arguments policy %s@%s", policyName, vhost)
+
+		resp, err := rmqc.DeletePolicy(vhost, policyName)
+		log.Printf("[DEBUG] RabbitMQ: Policy delete response: %#v", resp)
+
+		var errorResponse rabbithole.ErrorResponse
+		if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 400 && resp.StatusCode != 404 {
+			return fmt.Errorf("Error deleting RabbitMQ policy: %s", resp.Status)
+		}
+		return nil
+	}
+
+	return putPolicy(rmqc, vhost, policyName, map[string]interface{}{
+		"pattern":    "^" + regexp.QuoteMeta(name) + "$",
+		"priority":   queueArgumentsPolicyPriority,
+		"apply_to":   "queues",
+		"definition": definition,
+	})
+}
+
+// mergeQueueArgumentsPolicy adds the arguments set by the policy of a queue to
+// its declared arguments, as strings when they are configured as such.
+func mergeQueueArgumentsPolicy(rmqc *rabbithole.Client, vhost string, name string, arguments map[string]interface{}, asStrings bool) (map[string]interface{}, error) {
+	merged := make(map[string]interface{})
+	for key, value := range arguments {
+		merged[key] = value
+	}
+
+	policy, err := rmqc.GetPolicy(vhost, queueArgumentsPolicyName(name))
+	var errorResponse rabbithole.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 {
+		return merged, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Queue arguments policy retrieved for %s@%s: %#v", name, vhost, policy)
+
+	for key, policyKey := range queuePolicyArguments {
+		value, ok := policy.Definition[policyKey]
+		if !ok {
+			continue
+		}
+		if asStrings {
+			value = formatValue(value)
+		}
+		merged[key] = value
+	}
+
+	return merged, nil
+}
@@ -1,9 +1,13 @@
 package rabbitmq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -54,6 +58,177 @@ func TestAccQueue_jsonArguments(t *testing.T) {
 	})
 }
 
+func TestAccQueue_purgeTrigger(t *testing.T) {
+	var queueInfo rabbithole.QueueInfo
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccQueueCheckDestroy(&queueInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQueueConfig_purgeTrigger("1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccQueueCheck("rabbitmq_queue.test", &queueInfo),
+					resource.TestCheckNoResourceAttr("rabbitmq_queue.test", "last_purged_at"),
+				),
+			},
+			{
+				PreConfig: func() {
+					rmqc := testAccProvider.Meta().(*providerMeta)
+					message := map[string]interface{}{
+						"properties":       map[string]interface{}{},
+						"routing_key":      "test",
+						"payload":          "poisoned",
+						"payload_encoding": "string",
+					}
+					if _, err := apiRequest(rmqc, "POST", "exchanges/test/amq.default/publish", message, nil); err != nil {
+						t.Fatalf("Error publishing message: %s", err)
+					}
+				},
+				Config: testAccQueueConfig_purgeTrigger("2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccQueueCheck("rabbitmq_queue.test", &queueInfo),
+					resource.TestCheckResourceAttrSet("rabbitmq_queue.test", "last_purged_at"),
+					testAccQueueCheckEmpty(&queueInfo),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQueue_argumentsPolicy(t *testing.T) {
+	var queueInfo rabbithole.QueueInfo
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccQueueCheckDestroy(&queueInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQueueConfig_argumentsPolicy(`{"x-queue-type": "quorum", "x-max-length": 100}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccQueueCheck("rabbitmq_queue.test", &queueInfo),
+					testAccQueueCheckArgumentsPolicy(&queueInfo, map[string]interface{}{"max-length": 100.0}),
+					resource.TestCheckResourceAttr("rabbitmq_queue.test", "arguments_policy", "queue-arguments-test"),
+				),
+			},
+			{
+				Config: testAccQueueConfig_argumentsPolicy(`{"x-queue-type": "quorum", "x-max-length": 200, "x-delivery-limit": 5}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccQueueCheck("rabbitmq_queue.test", &queueInfo),
+					testAccQueueCheckArgumentsPolicy(&queueInfo, map[string]interface{}{"max-length": 200.0, "delivery-limit": 5.0}),
+				),
+			},
+			{
+				Config: testAccQueueConfig_argumentsPolicy(`{"x-queue-type": "quorum"}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccQueueCheck("rabbitmq_queue.test", &queueInfo),
+					testAccQueueCheckArgumentsPolicy(&queueInfo, nil),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQueue_conflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					rmqc := testAccProvider.Meta().(*providerMeta).Client
+					settings := rabbithole.QueueSettings{Durable: true, Arguments: map[string]interface{}{"x-max-length": 10}}
+					if _, err := rmqc.DeclareQueue("/", "conflict", settings); err != nil {
+						t.Fatalf("Error declaring queue: %s", err)
+					}
+				},
+				Config:      testAccQueueConfig_conflict,
+				ExpectError: regexp.MustCompile("already exists with different properties: argument x-max-length is 10 instead of 20"),
+			},
+		},
+	})
+
+	rmqc := testAccProvider.Meta().(*providerMeta).Client
+	if _, err := rmqc.DeleteQueue("/", "conflict"); err != nil {
+		t.Errorf("Error deleting queue: %s", err)
+	}
+}
+
+func TestSplitQueueArguments(t *testing.T) {
+	declared, definition := splitQueueArguments(map[string]interface{}{
+		"x-queue-type":             "quorum",
+		"x-max-length":             100,
+		"x-dead-letter-exchange":   "dlx",
+		"x-single-active-consumer": true,
+	})
+
+	expectedDeclared := map[string]interface{}{"x-queue-type": "quorum", "x-single-active-consumer": true}
+	if !reflect.DeepEqual(declared, expectedDeclared) {
+		t.Errorf("Expected declared arguments %v, got %v", expectedDeclared, declared)
+	}
+
+	expectedDefinition := map[string]interface{}{"max-length": 100, "dead-letter-exchange": "dlx"}
+	if !reflect.DeepEqual(definition, expectedDefinition) {
+		t.Errorf("Expected policy definition %v, got %v", expectedDefinition, definition)
+	}
+}
+
+func TestCustomizeQueueDiff_argumentsUpdateStrategy(t *testing.T) {
+	// The queue doesn't exist yet when it is replaced
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	meta, err := newProviderMeta(server.URL, "guest", "guest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff := func(arguments map[string]interface{}) (*terraform.InstanceDiff, error) {
+		attributes := map[string]string{
+			"name":                      "orders",
+			"vhost":                     "/",
+			"arguments_update_strategy": "replace",
+			"settings.#":                "1",
+			"settings.0.durable":        "true",
+			"settings.0.auto_delete":    "false",
+			"settings.0.arguments.%":    fmt.Sprint(len(arguments)),
+		}
+		for key, value := range arguments {
+			attributes["settings.0.arguments."+key] = value.(string)
+		}
+		state := &terraform.InstanceState{ID: "orders@/", Attributes: attributes}
+
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":                      "orders",
+			"vhost":                     "/",
+			"arguments_update_strategy": "policy",
+			"settings": []interface{}{map[string]interface{}{
+				"durable":   true,
+				"arguments": arguments,
+			}},
+		})
+		return resourceQueue().Diff(context.Background(), state, config, meta)
+	}
+
+	// Without arguments set by the policy, the strategy is switched in place
+	d, err := diff(map[string]interface{}{"x-queue-type": "quorum"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.RequiresNew() {
+		t.Errorf("Expected the strategy to be switched in place, got %#v", d.Attributes)
+	}
+
+	// The arguments moving to the policy can't be removed from the queue
+	d, err = diff(map[string]interface{}{"x-queue-type": "quorum", "x-max-length": "10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.RequiresNew() {
+		t.Errorf("Expected the queue to be replaced, got %#v", d.Attributes)
+	}
+}
+
 func testAccQueueCheck(rn string, queueInfo *rabbithole.QueueInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -65,7 +240,7 @@ func testAccQueueCheck(rn string, queueInfo *rabbithole.QueueInfo) resource.Test
 			return fmt.Errorf("queue id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 		queueParts := strings.Split(rs.Primary.ID, "@")
 
 		queues, err := rmqc.ListQueuesIn(queueParts[1])
@@ -110,9 +285,59 @@ func testAccQueueCheckJsonArguments(rn string, queueInfo *rabbithole.QueueInfo,
 	}
 }
 
+func testAccQueueCheckEmpty(queueInfo *rabbithole.QueueInfo) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*providerMeta)
+
+		request := map[string]interface{}{
+			"count":    1,
+			"ackmode":  "ack_requeue_true",
+			"encoding": "auto",
+		}
+		var messages []interface{}
+		path := fmt.Sprintf("queues/%s/%s/get", queueInfo.Vhost, queueInfo.Name)
+		if _, err := apiRequest(rmqc, "POST", path, request, &messages); err != nil {
+			return fmt.Errorf("Error getting messages: %s", err)
+		}
+
+		if len(messages) > 0 {
+			return fmt.Errorf("Queue %s was not purged", queueInfo.Name)
+		}
+
+		return nil
+	}
+}
+
+func testAccQueueCheckArgumentsPolicy(queueInfo *rabbithole.QueueInfo, definition map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+
+		policy, err := rmqc.GetPolicy(queueInfo.Vhost, queueArgumentsPolicyName(queueInfo.Name))
+		if definition == nil {
+			if err == nil {
+				return fmt.Errorf("Queue arguments policy of %s still exists", queueInfo.Name)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Error retrieving queue arguments policy: %s", err)
+		}
+
+		if !reflect.DeepEqual(map[string]interface{}(policy.Definition), definition) {
+			return fmt.Errorf("Expected queue arguments policy %v, got %v", definition, policy.Definition)
+		}
+
+		if _, ok := queueInfo.Arguments["x-max-length"]; ok {
+			return fmt.Errorf("Queue %s was declared with x-max-length", queueInfo.Name)
+		}
+
+		return nil
+	}
+}
+
 func testAccQueueCheckDestroy(queueInfo *rabbithole.QueueInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 
 		queues, err := rmqc.ListQueuesIn(queueInfo.Vhost)
 		if err != nil && !strings.Contains(strings.ToLower(err.Error()), "not found") {
@@ -209,3 +434,70 @@ resource "rabbitmq_queue" "test" {
 	}
 }`, j)
 }
+
+func testAccQueueConfig_purgeTrigger(trigger string) string {
+	return fmt.Sprintf(`
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    purge_trigger = "%s"
+    settings {
+        durable = true
+        auto_delete = false
+    }
+}`, trigger)
+}
+
+func testAccQueueConfig_argumentsPolicy(arguments string) string {
+	return fmt.Sprintf(`
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    arguments_update_strategy = "policy"
+    settings {
+        durable = true
+        arguments_json = <<EOF
+%s
+EOF
+    }
+}`, arguments)
+}
+
+const testAccQueueConfig_conflict = `
+resource "rabbitmq_queue" "test" {
+    name = "conflict"
+    settings {
+        durable = true
+        arguments_json = jsonencode({
+            "x-max-length" = 20
+        })
+    }
+}`
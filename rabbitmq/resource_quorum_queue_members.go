@@ -0,0 +1,196 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceQuorumQueueMembers() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateQuorumQueueMembers,
+		Update: UpdateQuorumQueueMembers,
+		Read:   ReadQuorumQueueMembers,
+		Delete: DeleteQuorumQueueMembers,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+				ForceNew: true,
+			},
+
+			"members": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"leader": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		CustomizeDiff: customizeQuorumQueueMembersDiff,
+	}
+}
+
+func CreateQuorumQueueMembers(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta)
+
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+
+	if err := setQuorumQueueMembers(rmqc, vhost, name, d.Get("members").(*schema.Set)); err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("%s@%s", name, vhost)
+	d.SetId(id)
+
+	return ReadQuorumQueueMembers(d, meta)
+}
+
+func ReadQuorumQueueMembers(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta)
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return err
+	}
+
+	queue, err := rmqc.GetQueue(vhost, name)
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Quorum queue members retrieved for %s: %v", d.Id(), queue.Members)
+
+	d.Set("name", queue.Name)
+	d.Set("vhost", queue.Vhost)
+	d.Set("members", queue.Members)
+	d.Set("leader", queue.Leader)
+
+	return nil
+}
+
+func UpdateQuorumQueueMembers(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta)
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return err
+	}
+
+	if err := setQuorumQueueMembers(rmqc, vhost, name, d.Get("members").(*schema.Set)); err != nil {
+		return err
+	}
+
+	return ReadQuorumQueueMembers(d, meta)
+}
+
+// DeleteQuorumQueueMembers leaves the replicas of the queue as they are, they
+// are removed with the queue itself.
+func DeleteQuorumQueueMembers(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] RabbitMQ: Quorum queue members of %s are no longer managed", d.Id())
+
+	return nil
+}
+
+// setQuorumQueueMembers adds the missing members to the queue before removing
+// the extra ones, so that the queue keeps as many replicas as possible.
+func setQuorumQueueMembers(rmqc *providerMeta, vhost string, name string, members *schema.Set) error {
+	queue, err := rmqc.GetQueue(vhost, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving RabbitMQ queue %s@%s: %s", name, vhost, err)
+	}
+
+	if queue.Type != "quorum" {
+		return fmt.Errorf("RabbitMQ queue %s@%s is not a quorum queue", name, vhost)
+	}
+
+	current := make(map[string]bool)
+	for _, node := range queue.Members {
+		current[node] = true
+	}
+
+	for _, node := range members.List() {
+		if !current[node.(string)] {
+			if err := quorumQueueMemberRequest(rmqc, vhost, name, "add", node.(string)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, node := range queue.Members {
+		if !members.Contains(node) {
+			if err := quorumQueueMemberRequest(rmqc, vhost, name, "delete", node); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// quorumQueueMemberRequest adds a member to a quorum queue, or deletes one.
+func quorumQueueMemberRequest(rmqc *providerMeta, vhost string, name string, action string, node string) error {
+	method := "POST"
+	if action == "delete" {
+		method = "DELETE"
+	}
+
+	path := fmt.Sprintf("queues/quorum/%s/%s/replicas/%s", url.PathEscape(vhost), url.PathEscape(name), action)
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to %s member %s of quorum queue %s@%s", action, node, name, vhost)
+
+	resp, err := apiRequest(rmqc, method, path, map[string]string{"node": node}, nil)
+	log.Printf("[DEBUG] RabbitMQ: Quorum queue member response: %#v", resp)
+	if err != nil {
+		return fmt.Errorf("Error updating the members of RabbitMQ quorum queue %s@%s: %s", name, vhost, err)
+	}
+
+	return nil
+}
+
+// customizeQuorumQueueMembersDiff checks that the members are nodes of the
+// cluster at plan time.
+func customizeQuorumQueueMembersDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("members") || !d.NewValueKnown("members") {
+		return nil
+	}
+
+	rmqc := meta.(*providerMeta)
+	nodes, err := rmqc.ListNodes()
+	if err != nil {
+		return fmt.Errorf("Error retrieving RabbitMQ nodes: %s", err)
+	}
+
+	known := make(map[string]bool)
+	for _, node := range nodes {
+		known[node.Name] = true
+	}
+
+	for _, member := range d.Get("members").(*schema.Set).List() {
+		if !known[member.(string)] {
+			return fmt.Errorf("Unknown RabbitMQ node %q in the members of quorum queue %s", member, d.Get("name"))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccQuorumQueueMembers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQuorumQueueMembersConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccQuorumQueueMembersCheck("rabbitmq_quorum_queue_members.test"),
+					resource.TestCheckResourceAttr("rabbitmq_quorum_queue_members.test", "members.#", "1"),
+					resource.TestCheckResourceAttrPair("rabbitmq_quorum_queue_members.test", "leader", "data.rabbitmq_nodes.all", "names.0"),
+					resource.TestCheckResourceAttrPair("rabbitmq_queue.test", "leader", "data.rabbitmq_nodes.all", "names.0"),
+					resource.TestCheckResourceAttr("rabbitmq_queue.test", "members.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "rabbitmq_quorum_queue_members.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccQuorumQueueMembers_unknownNode(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccQuorumQueueMembersConfig_unknownNode,
+				ExpectError: regexp.MustCompile("Unknown RabbitMQ node \"rabbit@unknown\""),
+			},
+		},
+	})
+}
+
+func testAccQuorumQueueMembersCheck(rn string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		name, vhost, err := parseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		queue, err := rmqc.GetQueue(vhost, name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving queue: %s", err)
+		}
+
+		if fmt.Sprint(len(queue.Members)) != rs.Primary.Attributes["members.#"] {
+			return fmt.Errorf("Queue %s has members %v", rs.Primary.ID, queue.Members)
+		}
+
+		return nil
+	}
+}
+
+const testAccQuorumQueueMembersConfig_basic = `
+data "rabbitmq_nodes" "all" {}
+
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    settings {
+        durable = true
+        arguments = {
+            "x-queue-type" = "quorum"
+        }
+    }
+}
+
+resource "rabbitmq_quorum_queue_members" "test" {
+    name = rabbitmq_queue.test.name
+    vhost = rabbitmq_queue.test.vhost
+    members = data.rabbitmq_nodes.all.names
+}`
+
+const testAccQuorumQueueMembersConfig_unknownNode = `
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    settings {
+        durable = true
+        arguments = {
+            "x-queue-type" = "quorum"
+        }
+    }
+}
+
+resource "rabbitmq_quorum_queue_members" "test" {
+    name = rabbitmq_queue.test.name
+    vhost = rabbitmq_queue.test.vhost
+    members = ["rabbit@unknown"]
+}`
@@ -196,12 +196,14 @@ func resourceShovel() *schema.Resource {
 					},
 				},
 			},
+
+			"on_conflict": onConflictSchema(),
 		},
 	}
 }
 
 func CreateShovel(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	vhost := d.Get("vhost").(string)
 	shovelName := d.Get("name").(string)
@@ -214,13 +216,23 @@ func CreateShovel(d *schema.ResourceData, meta interface{}) error {
 
 	shovelDefinition := setShovelDefinition(shovelMap).(rabbithole.ShovelDefinition)
 
-	log.Printf("[DEBUG] RabbitMQ: Attempting to declare shovel %s in vhost %s", shovelName, vhost)
-	resp, err := rmqc.DeclareShovel(vhost, shovelName, shovelDefinition)
-	log.Printf("[DEBUG] RabbitMQ: shovel declartion response: %#v", resp)
+	action, err := checkConflict(d, meta.(*providerMeta), "overwrite", fmt.Sprintf("RabbitMQ shovel %s@%s", shovelName, vhost), func() error {
+		_, err := rmqc.GetShovel(vhost, shovelName)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
+	if action != "adopt" {
+		log.Printf("[DEBUG] RabbitMQ: Attempting to declare shovel %s in vhost %s", shovelName, vhost)
+		resp, err := rmqc.DeclareShovel(vhost, shovelName, shovelDefinition)
+		log.Printf("[DEBUG] RabbitMQ: shovel declartion response: %#v", resp)
+		if err != nil {
+			return err
+		}
+	}
+
 	shovelId := fmt.Sprintf("%s@%s", shovelName, vhost)
 
 	d.SetId(shovelId)
@@ -229,7 +241,7 @@ func CreateShovel(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadShovel(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -281,7 +293,7 @@ func ReadShovel(d *schema.ResourceData, meta interface{}) error {
 }
 
 func UpdateShovel(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -310,7 +322,7 @@ func UpdateShovel(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeleteShovel(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -0,0 +1,373 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// A super stream is a direct exchange routing to one stream per partition,
+// declared the same way as by rabbitmq-streams add_super_stream.
+const superStreamPartitionOrder = "x-stream-partition-order"
+
+func resourceSuperStream() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateSuperStream,
+		Update: UpdateSuperStream,
+		Read:   ReadSuperStream,
+		Delete: DeleteSuperStream,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+				ForceNew: true,
+			},
+
+			"partitions": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"partitions", "routing_keys"},
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"routing_keys": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"partitions", "routing_keys"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"arguments_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+
+			"streams": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+
+		CustomizeDiff: customdiff.All(
+			customdiff.ForceNewIfChange("partitions", func(ctx context.Context, old, new, meta interface{}) bool {
+				return new.(int) < old.(int)
+			}),
+			customdiff.ForceNewIfChange("routing_keys", func(ctx context.Context, old, new, meta interface{}) bool {
+				return !superStreamKeysGrow(old.([]interface{}), new.([]interface{}))
+			}),
+			customizeSuperStreamDiff,
+		),
+	}
+}
+
+func CreateSuperStream(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+
+	arguments, err := superStreamArguments(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to declare super stream %s@%s", name, vhost)
+
+	exchangeSettings := rabbithole.ExchangeSettings{
+		Type:      "direct",
+		Durable:   true,
+		Arguments: map[string]interface{}{"x-super-stream": true},
+	}
+	resp, err := rmqc.DeclareExchange(vhost, name, exchangeSettings)
+	log.Printf("[DEBUG] RabbitMQ: Super stream exchange declare response: %#v", resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("Error declaring RabbitMQ super stream: %s", resp.Status)
+	}
+
+	// A super stream is only usable with all its partitions, the streams
+	// already declared are deleted with the exchange on failure.
+	if err := declareSuperStreamPartitions(rmqc, vhost, name, superStreamRoutingKeys(d), arguments); err != nil {
+		if err := deleteSuperStream(rmqc, vhost, name); err != nil {
+			log.Printf("[DEBUG] RabbitMQ: Unable to delete incomplete super stream %s@%s: %s", name, vhost, err)
+		}
+		return err
+	}
+
+	id := fmt.Sprintf("%s@%s", name, vhost)
+	d.SetId(id)
+
+	return ReadSuperStream(d, meta)
+}
+
+func ReadSuperStream(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return err
+	}
+
+	if _, err := rmqc.GetExchange(vhost, name); err != nil {
+		return checkDeleted(d, err)
+	}
+
+	bindings, err := rmqc.ListExchangeBindingsWithSource(vhost, name)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Super stream bindings retrieved for %s: %#v", d.Id(), bindings)
+
+	sort.SliceStable(bindings, func(i, j int) bool {
+		return superStreamPartitionIndex(bindings[i]) < superStreamPartitionIndex(bindings[j])
+	})
+
+	routingKeys := make([]string, 0, len(bindings))
+	streams := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		routingKeys = append(routingKeys, binding.RoutingKey)
+		streams = append(streams, binding.Destination)
+	}
+
+	d.Set("name", name)
+	d.Set("vhost", vhost)
+	d.Set("partitions", len(bindings))
+	d.Set("routing_keys", routingKeys)
+	d.Set("streams", streams)
+
+	if len(streams) > 0 {
+		stream, err := rmqc.GetQueue(vhost, streams[0])
+		if err != nil {
+			return err
+		}
+
+		arguments := make(map[string]interface{})
+		for key, value := range stream.Arguments {
+			if key != "x-queue-type" {
+				arguments[key] = value
+			}
+		}
+
+		if _, ok := d.GetOk("arguments_json"); ok || len(arguments) > 0 {
+			bytes, err := json.Marshal(arguments)
+			if err != nil {
+				return err
+			}
+			d.Set("arguments_json", string(bytes))
+		}
+	}
+
+	return nil
+}
+
+// UpdateSuperStream adds the new partitions. Declaring the existing ones again
+// is a no-op, and recreates the ones which were deleted.
+func UpdateSuperStream(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return err
+	}
+
+	arguments, err := superStreamArguments(d)
+	if err != nil {
+		return err
+	}
+
+	if err := declareSuperStreamPartitions(rmqc, vhost, name, superStreamRoutingKeys(d), arguments); err != nil {
+		return err
+	}
+
+	return ReadSuperStream(d, meta)
+}
+
+func DeleteSuperStream(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete super stream for %s", d.Id())
+
+	return deleteSuperStream(rmqc, vhost, name)
+}
+
+// declareSuperStreamPartitions declares the stream of each routing key, and
+// binds it to the exchange with the index of its partition.
+func declareSuperStreamPartitions(rmqc *rabbithole.Client, vhost string, name string, routingKeys []string, arguments map[string]interface{}) error {
+	for i, routingKey := range routingKeys {
+		stream := superStreamPartitionName(name, routingKey)
+
+		resp, err := rmqc.DeclareQueue(vhost, stream, rabbithole.QueueSettings{
+			Durable:   true,
+			Arguments: arguments,
+		})
+		log.Printf("[DEBUG] RabbitMQ: Super stream partition declare response: %#v", resp)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("Error declaring RabbitMQ super stream partition %s: %s", stream, resp.Status)
+		}
+
+		resp, err = rmqc.DeclareBinding(vhost, rabbithole.BindingInfo{
+			Source:          name,
+			Destination:     stream,
+			DestinationType: "queue",
+			RoutingKey:      routingKey,
+			Arguments:       map[string]interface{}{superStreamPartitionOrder: i},
+		})
+		log.Printf("[DEBUG] RabbitMQ: Super stream binding declare response: %#v", resp)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("Error declaring RabbitMQ super stream binding %s: %s", stream, resp.Status)
+		}
+	}
+
+	return nil
+}
+
+// deleteSuperStream deletes the streams bound to the exchange, then the
+// exchange itself.
+func deleteSuperStream(rmqc *rabbithole.Client, vhost string, name string) error {
+	bindings, err := rmqc.ListExchangeBindingsWithSource(vhost, name)
+	if err != nil {
+		return err
+	}
+
+	for _, binding := range bindings {
+		if binding.DestinationType != "queue" {
+			continue
+		}
+
+		resp, err := rmqc.DeleteQueue(vhost, binding.Destination)
+		log.Printf("[DEBUG] RabbitMQ: Super stream partition delete response: %#v", resp)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 400 && resp.StatusCode != 404 {
+			return fmt.Errorf("Error deleting RabbitMQ super stream partition %s: %s", binding.Destination, resp.Status)
+		}
+	}
+
+	resp, err := rmqc.DeleteExchange(vhost, name)
+	log.Printf("[DEBUG] RabbitMQ: Super stream exchange delete response: %#v", resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 && resp.StatusCode != 404 {
+		return fmt.Errorf("Error deleting RabbitMQ super stream: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// superStreamRoutingKeys returns the configured routing keys of the
+// partitions, which otherwise are their index.
+func superStreamRoutingKeys(d *schema.ResourceData) []string {
+	var routingKeys []string
+	if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("routing_keys").IsNull() {
+		for _, routingKey := range d.Get("routing_keys").([]interface{}) {
+			routingKeys = append(routingKeys, routingKey.(string))
+		}
+		return routingKeys
+	}
+
+	for i := 0; i < d.Get("partitions").(int); i++ {
+		routingKeys = append(routingKeys, strconv.Itoa(i))
+	}
+	return routingKeys
+}
+
+func superStreamArguments(d *schema.ResourceData) (map[string]interface{}, error) {
+	arguments := make(map[string]interface{})
+	if v, ok := d.Get("arguments_json").(string); ok && v != "" {
+		if err := json.Unmarshal([]byte(v), &arguments); err != nil {
+			return nil, err
+		}
+	}
+	arguments["x-queue-type"] = "stream"
+
+	return arguments, nil
+}
+
+func superStreamPartitionName(name string, routingKey string) string {
+	return fmt.Sprintf("%s-%s", name, routingKey)
+}
+
+func superStreamPartitionIndex(binding rabbithole.BindingInfo) float64 {
+	index, _ := binding.Arguments[superStreamPartitionOrder].(float64)
+	return index
+}
+
+// superStreamKeysGrow returns whether the new routing keys only add partitions
+// after the existing ones.
+func superStreamKeysGrow(old []interface{}, new []interface{}) bool {
+	if len(new) < len(old) {
+		return false
+	}
+	for i := range old {
+		if old[i] != new[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// customizeSuperStreamDiff plans the partitions, routing keys and streams from
+// whichever of the first two is configured.
+func customizeSuperStreamDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.HasChange("partitions") {
+		if err := d.SetNewComputed("routing_keys"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("streams")
+	}
+
+	if d.HasChange("routing_keys") {
+		if err := d.SetNewComputed("partitions"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("streams")
+	}
+
+	return nil
+}
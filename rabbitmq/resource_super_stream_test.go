@@ -0,0 +1,148 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSuperStream_partitions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccSuperStreamCheckDestroy("invoices", "/"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSuperStreamConfig_partitions(3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSuperStreamCheck("rabbitmq_super_stream.test", 3),
+					resource.TestCheckResourceAttr("rabbitmq_super_stream.test", "routing_keys.#", "3"),
+					resource.TestCheckResourceAttr("rabbitmq_super_stream.test", "routing_keys.2", "2"),
+					resource.TestCheckResourceAttr("rabbitmq_super_stream.test", "streams.0", "invoices-0"),
+				),
+			},
+			{
+				Config: testAccSuperStreamConfig_partitions(5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSuperStreamCheck("rabbitmq_super_stream.test", 5),
+					resource.TestCheckResourceAttr("rabbitmq_super_stream.test", "streams.4", "invoices-4"),
+				),
+			},
+			{
+				ResourceName:      "rabbitmq_super_stream.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSuperStream_routingKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccSuperStreamCheckDestroy("invoices", "/"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSuperStreamConfig_routingKeys(`["amer", "emea"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSuperStreamCheck("rabbitmq_super_stream.test", 2),
+					resource.TestCheckResourceAttr("rabbitmq_super_stream.test", "partitions", "2"),
+					resource.TestCheckResourceAttr("rabbitmq_super_stream.test", "streams.1", "invoices-emea"),
+				),
+			},
+			{
+				Config: testAccSuperStreamConfig_routingKeys(`["amer", "emea", "apac"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSuperStreamCheck("rabbitmq_super_stream.test", 3),
+					resource.TestCheckResourceAttr("rabbitmq_super_stream.test", "streams.2", "invoices-apac"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSuperStreamCheck(rn string, partitions int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		name, vhost, err := parseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		bindings, err := rmqc.ListExchangeBindingsWithSource(vhost, name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving super stream bindings: %s", err)
+		}
+
+		if len(bindings) != partitions {
+			return fmt.Errorf("Expected %d partitions, got %d", partitions, len(bindings))
+		}
+
+		for _, binding := range bindings {
+			stream, err := rmqc.GetQueue(vhost, binding.Destination)
+			if err != nil {
+				return fmt.Errorf("Error retrieving super stream partition: %s", err)
+			}
+			if stream.Type != "stream" {
+				return fmt.Errorf("Partition %s is a %s queue", stream.Name, stream.Type)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccSuperStreamCheckDestroy(name string, vhost string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+
+		exchanges, err := rmqc.ListExchangesIn(vhost)
+		if err != nil {
+			return fmt.Errorf("Error retrieving exchanges: %s", err)
+		}
+		for _, exchange := range exchanges {
+			if exchange.Name == name {
+				return fmt.Errorf("Super stream %s@%s still exists", name, vhost)
+			}
+		}
+
+		queues, err := rmqc.ListQueuesIn(vhost)
+		if err != nil {
+			return fmt.Errorf("Error retrieving queues: %s", err)
+		}
+		for _, queue := range queues {
+			if queue.Type == "stream" {
+				return fmt.Errorf("Super stream partition %s@%s still exists", queue.Name, vhost)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccSuperStreamConfig_partitions(partitions int) string {
+	return fmt.Sprintf(`
+resource "rabbitmq_super_stream" "test" {
+    name = "invoices"
+    partitions = %d
+    arguments_json = jsonencode({
+        "x-max-length-bytes" = 20000000000
+    })
+}`, partitions)
+}
+
+func testAccSuperStreamConfig_routingKeys(routingKeys string) string {
+	return fmt.Sprintf(`
+resource "rabbitmq_super_stream" "test" {
+    name = "invoices"
+    routing_keys = %s
+}`, routingKeys)
+}
@@ -0,0 +1,207 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceTopicPermission manages the topic permissions of a user on a single
+// exchange, leaving the permissions on other exchanges untouched.
+func resourceTopicPermission() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateTopicPermission,
+		Update: UpdateTopicPermission,
+		Read:   ReadTopicPermission,
+		Delete: DeleteTopicPermission,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+				ForceNew: true,
+			},
+
+			"exchange": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"write": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"read": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"on_conflict": onConflictSchema(),
+		},
+	}
+}
+
+// CreateTopicPermission for the given exchange
+func CreateTopicPermission(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	user := d.Get("user").(string)
+	vhost := d.Get("vhost").(string)
+	exchange := d.Get("exchange").(string)
+
+	permsMap := map[string]interface{}{
+		"exchange": exchange,
+		"write":    d.Get("write").(string),
+		"read":     d.Get("read").(string),
+	}
+
+	// Permissions were always overwritten before on_conflict, which is kept as
+	// the default
+	description := fmt.Sprintf("RabbitMQ topic permission of user %s on exchange %s in vhost %s", user, exchange, vhost)
+	action, err := checkConflict(d, meta.(*providerMeta), "overwrite", description, func() error {
+		perms, err := rmqc.GetTopicPermissionsIn(vhost, user)
+		if err != nil {
+			return err
+		}
+		for _, perm := range perms {
+			if perm.Exchange == exchange {
+				return nil
+			}
+		}
+		return rabbithole.ErrorResponse{StatusCode: 404}
+	})
+	if err != nil {
+		return err
+	}
+
+	if action != "adopt" {
+		if err := setTopicPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(topicPermissionId(user, vhost, exchange))
+
+	return ReadTopicPermission(d, meta)
+}
+
+// ReadTopicPermission for the given ID
+func ReadTopicPermission(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	user, vhost, exchange, err := parseTopicPermissionId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	userPerms, err := rmqc.GetTopicPermissionsIn(vhost, user)
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Topic permissions retrieved for %s: %#v", d.Id(), userPerms)
+
+	for _, perm := range userPerms {
+		if perm.Exchange != exchange {
+			continue
+		}
+
+		d.Set("user", perm.User)
+		d.Set("vhost", perm.Vhost)
+		d.Set("exchange", perm.Exchange)
+		d.Set("write", perm.Write)
+		d.Set("read", perm.Read)
+
+		return nil
+	}
+
+	// The permission on this exchange could not be found,
+	// so consider it deleted and remove from state
+	d.SetId("")
+
+	return nil
+}
+
+// UpdateTopicPermission for the given ID
+func UpdateTopicPermission(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	user, vhost, exchange, err := parseTopicPermissionId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	permsMap := map[string]interface{}{
+		"exchange": exchange,
+		"write":    d.Get("write").(string),
+		"read":     d.Get("read").(string),
+	}
+
+	if err := setTopicPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
+		return err
+	}
+
+	return ReadTopicPermission(d, meta)
+}
+
+// DeleteTopicPermission for the given ID
+func DeleteTopicPermission(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*providerMeta).Client
+
+	user, vhost, exchange, err := parseTopicPermissionId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete topic permission for %s", d.Id())
+
+	resp, err := rmqc.DeleteTopicPermissionsIn(vhost, user, exchange)
+	log.Printf("[DEBUG] RabbitMQ: Topic permission delete response: %#v", resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		// The permission was already deleted
+		return nil
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("Error deleting RabbitMQ topic permission: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// The ID is built as user/vhost/exchange, see percentEncodeSlashes
+func topicPermissionId(user, vhost, exchange string) string {
+	return fmt.Sprintf("%s/%s/%s", percentEncodeSlashes(user), percentEncodeSlashes(vhost), percentEncodeSlashes(exchange))
+}
+
+func parseTopicPermissionId(resourceId string) (user, vhost, exchange string, err error) {
+	parts := strings.Split(resourceId, "/")
+	if len(parts) != 3 {
+		err = fmt.Errorf("Unable to parse topic permission id: %s", resourceId)
+		return
+	}
+	user = percentDecodeSlashes(parts[0])
+	vhost = percentDecodeSlashes(parts[1])
+	exchange = percentDecodeSlashes(parts[2])
+	return
+}
@@ -0,0 +1,156 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTopicPermission(t *testing.T) {
+	if os.Getenv("RABBITMQ_VERSION") == "3.6" {
+		t.Skip("Topic permissions were added in RabbitMQ 3.7")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccTopicPermissionCheckDestroy("mctest", "test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTopicPermissionConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccTopicPermissionCheck("rabbitmq_topic_permission.foo", ".*", ".*"),
+					testAccTopicPermissionCheck("rabbitmq_topic_permission.bar", "^bar", ""),
+				),
+			},
+			{
+				// Removing one of them must leave the other exchange untouched
+				Config: testAccTopicPermissionConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccTopicPermissionCheck("rabbitmq_topic_permission.foo", ".*", "^foo"),
+					testAccTopicPermissionCheckAbsent("mctest", "test", "amq.direct"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTopicPermissionCheck(rn string, write string, read string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("topic permission id not set")
+		}
+
+		user, vhost, exchange, err := parseTopicPermissionId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		perms, err := rmqc.GetTopicPermissionsIn(vhost, user)
+		if err != nil {
+			return fmt.Errorf("Error retrieving topic permissions: %s", err)
+		}
+
+		for _, perm := range perms {
+			if perm.Exchange == exchange {
+				if perm.Write != write || perm.Read != read {
+					return fmt.Errorf("Unexpected topic permission for %s: %#v", rs.Primary.ID, perm)
+				}
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Unable to find topic permission %s", rn)
+	}
+}
+
+func testAccTopicPermissionCheckAbsent(user, vhost, exchange string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		perms, err := rmqc.ListTopicPermissionsOf(user)
+		if err != nil {
+			return fmt.Errorf("Error retrieving topic permissions: %s", err)
+		}
+
+		for _, perm := range perms {
+			if perm.Vhost == vhost && perm.Exchange == exchange {
+				return fmt.Errorf("Topic permission still exists for %s@%s on %s", user, vhost, exchange)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccTopicPermissionCheckDestroy(user, vhost string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		perms, err := rmqc.ListTopicPermissions()
+		if err != nil {
+			return fmt.Errorf("Error retrieving topic permissions: %s", err)
+		}
+
+		for _, perm := range perms {
+			if perm.User == user && perm.Vhost == vhost {
+				return fmt.Errorf("Topic permissions still exist for user %s@%s", user, vhost)
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccTopicPermissionConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+    tags = ["administrator"]
+}
+
+resource "rabbitmq_topic_permission" "foo" {
+    user = rabbitmq_user.test.name
+    vhost = rabbitmq_vhost.test.name
+    exchange = "amq.topic"
+    write = ".*"
+    read = ".*"
+}
+
+resource "rabbitmq_topic_permission" "bar" {
+    user = rabbitmq_user.test.name
+    vhost = rabbitmq_vhost.test.name
+    exchange = "amq.direct"
+    write = "^bar"
+    read = ""
+}`
+
+const testAccTopicPermissionConfig_update = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+    tags = ["administrator"]
+}
+
+resource "rabbitmq_topic_permission" "foo" {
+    user = rabbitmq_user.test.name
+    vhost = rabbitmq_vhost.test.name
+    exchange = "amq.topic"
+    write = ".*"
+    read = "^foo"
+}`
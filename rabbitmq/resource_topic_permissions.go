@@ -56,28 +56,58 @@ func resourceTopicPermissions() *schema.Resource {
 					},
 				},
 			},
+
+			"on_conflict": onConflictSchema(),
 		},
 	}
 }
 
 // CreateTopicPermissions for given exchanges
 func CreateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	user := d.Get("user").(string)
 	vhost := d.Get("vhost").(string)
 	permsSet := d.Get("permissions").(*schema.Set)
 
-	for _, exchange := range permsSet.List() {
-
-		permsMap, ok := exchange.(map[string]interface{})
-		if !ok {
-			return fmt.Errorf("Unable to parse permissions")
+	// Permissions were always overwritten before on_conflict, which is kept as
+	// the default. Only the configured exchanges conflict, so that the
+	// permissions on other exchanges can be managed with
+	// rabbitmq_topic_permission.
+	exchanges := make(map[string]bool)
+	for _, perms := range permsSet.List() {
+		if permsMap, ok := perms.(map[string]interface{}); ok {
+			exchanges[permsMap["exchange"].(string)] = true
 		}
-
-		if err := setTopicPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
+	}
+	action, err := checkConflict(d, meta.(*providerMeta), "overwrite", fmt.Sprintf("RabbitMQ topic permissions of user %s in vhost %s", user, vhost), func() error {
+		perms, err := rmqc.GetTopicPermissionsIn(vhost, user)
+		if err != nil {
 			return err
 		}
+		for _, perm := range perms {
+			if exchanges[perm.Exchange] {
+				return nil
+			}
+		}
+		return rabbithole.ErrorResponse{StatusCode: 404}
+	})
+	if err != nil {
+		return err
+	}
+
+	if action != "adopt" {
+		for _, exchange := range permsSet.List() {
+
+			permsMap, ok := exchange.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("Unable to parse permissions")
+			}
+
+			if err := setTopicPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
+				return err
+			}
+		}
 	}
 
 	id := fmt.Sprintf("%s@%s", user, vhost)
@@ -88,7 +118,7 @@ func CreateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 
 // ReadTopicPermissions for the given ID
 func ReadTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -120,7 +150,7 @@ func ReadTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 
 // UpdateTopicPermissions for given ID
 func UpdateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -151,7 +181,7 @@ func UpdateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 
 // DeleteTopicPermissions for given ID
 func DeleteTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -1,12 +1,17 @@
 package rabbitmq
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"strings"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceUser() *schema.Resource {
@@ -16,7 +21,10 @@ func resourceUser() *schema.Resource {
 		Read:   ReadUser,
 		Delete: DeleteUser,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStatePassthroughWithDefaults(map[string]interface{}{
+				"verify_password":   false,
+				"allow_custom_tags": false,
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -27,40 +35,92 @@ func resourceUser() *schema.Resource {
 			},
 
 			"password": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"password_hash"},
+			},
+
+			"password_hash": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"password"},
+			},
+
+			"hashing_algorithm": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"password"},
+				RequiredWith:  []string{"password_hash"},
+				ValidateFunc: validation.StringInSlice([]string{
+					string(rabbithole.HashingAlgorithmSHA256),
+					string(rabbithole.HashingAlgorithmSHA512),
+					string(rabbithole.HashingAlgorithmMD5),
+				}, false),
+			},
+
+			"verify_password": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 
 			"tags": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+
+			"allow_custom_tags": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"on_conflict": onConflictSchema(),
 		},
+
+		CustomizeDiff: validateUserTags,
 	}
 }
 
+// Tags that have a meaning for RabbitMQ itself or its management plugin
+var knownUserTags = []string{
+	"administrator",
+	"monitoring",
+	"policymaker",
+	"management",
+	"impersonator",
+}
+
 func CreateUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name := d.Get("name").(string)
 
-	userSettings := rabbithole.UserSettings{
-		Password: d.Get("password").(string),
-		Tags:     userTagsToString(d),
-	}
-
-	log.Printf("[DEBUG] RabbitMQ: Attempting to create user %s", name)
-
-	resp, err := rmqc.PutUser(name, userSettings)
-	log.Printf("[DEBUG] RabbitMQ: user creation response: %#v", resp)
+	// Overwriting a user would reset the password of somebody else
+	action, err := checkConflict(d, meta.(*providerMeta), "error", fmt.Sprintf("RabbitMQ user %s", name), func() error {
+		_, err := rmqc.GetUser(name)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error creating RabbitMQ user: %s", resp.Status)
+	if action != "adopt" {
+		log.Printf("[DEBUG] RabbitMQ: Attempting to create user %s", name)
+
+		resp, err := putUser(rmqc, name, d)
+		log.Printf("[DEBUG] RabbitMQ: user creation response: %#v", resp)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("Error creating RabbitMQ user: %s", resp.Status)
+		}
 	}
 
 	d.SetId(name)
@@ -69,7 +129,7 @@ func CreateUser(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	user, err := rmqc.GetUser(d.Id())
 	if err != nil {
@@ -79,37 +139,57 @@ func ReadUser(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] RabbitMQ: User retrieved: %#v", user)
 
 	d.Set("name", user.Name)
+	d.Set("hashing_algorithm", user.HashingAlgorithm.String())
+
+	// Only track the hash when it is managed explicitly, otherwise every user
+	// created with a cleartext password would carry a computed hash as well.
+	if _, ok := d.GetOk("password_hash"); ok {
+		d.Set("password_hash", user.PasswordHash)
+	}
+
+	if d.Get("verify_password").(bool) {
+		if password, ok := d.GetOk("password"); ok {
+			matches, err := userPasswordMatches(meta.(*providerMeta), user, password.(string))
+			if err != nil {
+				return err
+			}
 
-	if len(user.Tags) > 0 {
-		var tagList []string
-		for _, v := range user.Tags {
-			if v != "" {
-				tagList = append(tagList, v)
+			if !matches {
+				// Forget the password so that the next plan updates it
+				log.Printf("[WARN] RabbitMQ: Password of user %s does not match the state", user.Name)
+				d.Set("password", "")
 			}
 		}
-		if len(tagList) > 0 {
-			d.Set("tags", tagList)
+	}
+
+	tagList := []string{}
+	for _, v := range user.Tags {
+		if v != "" {
+			tagList = append(tagList, v)
 		}
 	}
 
+	// RabbitMQ drops empty tags, keep the ones from the configuration
+	// so that they don't show up as a diff.
+	if d.Get("tags").(*schema.Set).Contains("") {
+		tagList = append(tagList, "")
+	}
+
+	if err := d.Set("tags", tagList); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func UpdateUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name := d.Id()
-	tags := userTagsToString(d)
-	password := d.Get("password").(string)
-
-	userSettings := rabbithole.UserSettings{
-		Password: password,
-		Tags:     tags,
-	}
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to update user %s", name)
 
-	resp, err := rmqc.PutUser(name, userSettings)
+	resp, err := putUser(rmqc, name, d)
 	log.Printf("[DEBUG] RabbitMQ: User update response: %#v", resp)
 	if err != nil {
 		return err
@@ -123,7 +203,7 @@ func UpdateUser(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeleteUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	name := d.Id()
 	log.Printf("[DEBUG] RabbitMQ: Attempting to delete user %s", name)
@@ -148,7 +228,7 @@ func DeleteUser(d *schema.ResourceData, meta interface{}) error {
 
 func userTagsToString(d *schema.ResourceData) rabbithole.UserTags {
 	tagList := rabbithole.UserTags{}
-	for _, v := range d.Get("tags").([]interface{}) {
+	for _, v := range d.Get("tags").(*schema.Set).List() {
 		if tag, ok := v.(string); ok {
 			tagList = append(tagList, tag)
 		}
@@ -156,3 +236,99 @@ func userTagsToString(d *schema.ResourceData) rabbithole.UserTags {
 
 	return tagList
 }
+
+func isKnownUserTag(tag string) bool {
+	for _, knownTag := range knownUserTags {
+		if tag == knownTag {
+			return true
+		}
+	}
+	return false
+}
+
+func validateUserTags(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("allow_custom_tags").(bool) || !d.NewValueKnown("tags") {
+		return nil
+	}
+
+	for _, v := range d.Get("tags").(*schema.Set).List() {
+		tag := v.(string)
+		if tag == "" {
+			continue
+		}
+
+		if !isKnownUserTag(tag) {
+			return fmt.Errorf("Unknown user tag %q, expected one of %s. Set allow_custom_tags to use custom tags", tag, strings.Join(knownUserTags, ", "))
+		}
+	}
+
+	return nil
+}
+
+// putUser creates or updates a user with either a cleartext password, a
+// pre-computed password hash, or no password at all.
+func putUser(rmqc *rabbithole.Client, name string, d *schema.ResourceData) (*http.Response, error) {
+	userSettings := rabbithole.UserSettings{
+		Tags: userTagsToString(d),
+	}
+
+	if v, ok := d.GetOk("password"); ok {
+		userSettings.Password = v.(string)
+		return rmqc.PutUser(name, userSettings)
+	}
+
+	if v, ok := d.GetOk("password_hash"); ok {
+		userSettings.PasswordHash = v.(string)
+		userSettings.HashingAlgorithm = rabbithole.HashingAlgorithm(d.Get("hashing_algorithm").(string))
+		return rmqc.PutUser(name, userSettings)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: No password given, user %s will be passwordless", name)
+
+	return rmqc.PutUserWithoutPassword(name, userSettings)
+}
+
+// userPasswordMatches checks if password is still the password of the user.
+// The stored hash is recomputed with its own salt when possible, otherwise it
+// falls back to authenticating as the user against /api/whoami.
+func userPasswordMatches(m *providerMeta, user *rabbithole.UserInfo, password string) (bool, error) {
+	if salt, err := passwordHashSalt(user.PasswordHash); err == nil {
+		hash, err := hashUserPassword(password, salt, user.HashingAlgorithm)
+		if err == nil {
+			return hash == user.PasswordHash, nil
+		}
+		log.Printf("[DEBUG] RabbitMQ: Unable to compute password hash for user %s: %s", user.Name, err)
+	}
+
+	// Users without a management tag are refused by the management API even
+	// with the right password, so their password cannot be verified this way
+	if !userHasManagementAccess(user.Tags) {
+		log.Printf("[WARN] RabbitMQ: Unable to verify the password of user %s, which has no access to the management API", user.Name)
+		return true, nil
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Verifying password of user %s with whoami", user.Name)
+
+	_, err := apiRequest(m.withCredentials(user.Name, password), "GET", "whoami", nil, nil)
+	var errorResponse rabbithole.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.StatusCode == 401 {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// userHasManagementAccess checks if the tags of a user allow it to use the
+// management API.
+func userHasManagementAccess(tags rabbithole.UserTags) bool {
+	for _, tag := range tags {
+		switch tag {
+		case "administrator", "monitoring", "policymaker", "management":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package rabbitmq
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceUserLimits() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateUserLimits,
+		Update: UpdateUserLimits,
+		Read:   ReadUserLimits,
+		Delete: DeleteUserLimits,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"max_connections": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+
+			"max_channels": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+		},
+	}
+}
+
+func CreateUserLimits(d *schema.ResourceData, meta interface{}) error {
+	return userLimits.create(d, meta)
+}
+
+func ReadUserLimits(d *schema.ResourceData, meta interface{}) error {
+	return userLimits.read(d, meta)
+}
+
+func UpdateUserLimits(d *schema.ResourceData, meta interface{}) error {
+	return userLimits.update(d, meta)
+}
+
+func DeleteUserLimits(d *schema.ResourceData, meta interface{}) error {
+	return userLimits.remove(d, meta)
+}
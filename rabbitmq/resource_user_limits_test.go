@@ -0,0 +1,130 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccUserLimits(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserLimitsCheckDestroy("mctest"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserLimitsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserLimitsCheck("rabbitmq_user_limits.test", rabbithole.UserLimitsValues{
+						"max-connections": 10,
+						"max-channels":    20,
+					}),
+					resource.TestCheckResourceAttr("data.rabbitmq_user_limits.test", "max_channels", "20"),
+				),
+			},
+			{
+				Config: testAccUserLimitsConfig_update,
+				Check: testAccUserLimitsCheck("rabbitmq_user_limits.test", rabbithole.UserLimitsValues{
+					"max-channels": 0,
+				}),
+			},
+			{
+				// Limits changed outside of Terraform must be detected
+				PreConfig: func() {
+					rmqc := testAccProvider.Meta().(*providerMeta).Client
+					if _, err := rmqc.PutUserLimits("mctest", rabbithole.UserLimitsValues{"max-channels": 5}); err != nil {
+						t.Fatalf("could not change user limits: %v", err)
+					}
+				},
+				Config:             testAccUserLimitsConfig_update,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccUserLimitsCheck(rn string, expected rabbithole.UserLimitsValues) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("user limits id not set")
+		}
+
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		limits, err := rmqc.GetUserLimits(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error retrieving user limits: %s", err)
+		}
+
+		values := rabbithole.UserLimitsValues{}
+		for _, limit := range limits {
+			values = limit.Value
+		}
+
+		if len(values) != len(expected) {
+			return fmt.Errorf("Expected user limits %v, got %v", expected, values)
+		}
+
+		for name, value := range expected {
+			if values[name] != value {
+				return fmt.Errorf("Expected user limits %v, got %v", expected, values)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccUserLimitsCheckDestroy(user string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		limits, err := rmqc.GetAllUserLimits()
+		if err != nil {
+			return fmt.Errorf("Error retrieving user limits: %s", err)
+		}
+
+		for _, limit := range limits {
+			if limit.User == user {
+				return fmt.Errorf("User limits still exist for %s", user)
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccUserLimitsConfig_basic = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+}
+
+resource "rabbitmq_user_limits" "test" {
+    user = rabbitmq_user.test.name
+    max_connections = 10
+    max_channels = 20
+}
+
+data "rabbitmq_user_limits" "test" {
+    user = rabbitmq_user_limits.test.user
+}`
+
+const testAccUserLimitsConfig_update = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+}
+
+resource "rabbitmq_user_limits" "test" {
+    user = rabbitmq_user.test.name
+    max_channels = 0
+}`
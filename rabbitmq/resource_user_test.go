@@ -2,6 +2,9 @@ package rabbitmq
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
 	"testing"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
@@ -62,6 +65,45 @@ func TestUpdateTags_password(t *testing.T) {
 	})
 }
 
+func TestUserPasswordMatches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "mctest" || password != "foobar" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "not_authorized", "reason": "Login failed"}`))
+			return
+		}
+		w.Write([]byte(`{"name": "mctest", "tags": ["management"]}`))
+	}))
+	defer server.Close()
+
+	m, err := newProviderMeta(server.URL, "guest", "guest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		password string
+		tags     rabbithole.UserTags
+		expected bool
+	}{
+		{"foobar", rabbithole.UserTags{"management"}, true},
+		{"changed", rabbithole.UserTags{"management"}, false},
+		// The password of users without management access isn't verified
+		{"changed", rabbithole.UserTags{}, true},
+	}
+
+	for _, c := range cases {
+		user := &rabbithole.UserInfo{Name: "mctest", Tags: c.tags}
+		matches, err := userPasswordMatches(m, user, c.password)
+		if err != nil {
+			t.Fatalf("Unexpected error verifying password %s: %s", c.password, err)
+		}
+		if matches != c.expected {
+			t.Errorf("Expected password %s with tags %v to match: %t", c.password, c.tags, c.expected)
+		}
+	}
+}
+
 func TestAccUser_emptyTag(t *testing.T) {
 	var user string
 	resource.Test(t, resource.TestCase{
@@ -144,6 +186,192 @@ func TestAccUser_passwordChange(t *testing.T) {
 	})
 }
 
+func TestAccUser_passwordHash(t *testing.T) {
+	var user string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy(user),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig_passwordHash,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserConnect("mctest", "foobar"),
+					resource.TestCheckResourceAttr("rabbitmq_user.test", "hashing_algorithm", "rabbit_password_hashing_sha256"),
+				),
+			},
+			{
+				Config: testAccUserConfig_passwordHashDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserConnect("mctest", "foobarry"),
+					resource.TestCheckResourceAttr("rabbitmq_user.test", "hashing_algorithm", "rabbit_password_hashing_sha512"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_passwordless(t *testing.T) {
+	var user string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy(user),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig_passwordless,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserCheckTagCount(&user, 1),
+					testAccUserCheckPasswordless(&user),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_verifyPassword(t *testing.T) {
+	var user string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy(user),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig_verifyPassword,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserConnect("mctest", "foobar"),
+				),
+			},
+			{
+				PreConfig: func() {
+					rmqc := testAccProvider.Meta().(*providerMeta).Client
+					resp, err := rmqc.PutUser("mctest", rabbithole.UserSettings{
+						Password: "changed",
+						Tags:     rabbithole.UserTags{"management"},
+					})
+					if err != nil || resp.StatusCode >= 400 {
+						t.Fatalf("could not change the password out-of-band: %v, %v", resp, err)
+					}
+				},
+				Config:             testAccUserConfig_verifyPassword,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccUserConfig_verifyPassword,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserConnect("mctest", "foobar"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_onConflict(t *testing.T) {
+	var user string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy(user),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					rmqc := testAccProvider.Meta().(*providerMeta).Client
+					resp, err := rmqc.PutUser("mctest", rabbithole.UserSettings{
+						Password: "existing",
+						Tags:     rabbithole.UserTags{"management"},
+					})
+					if err != nil || resp.StatusCode >= 400 {
+						t.Fatalf("could not create the user out-of-band: %v, %v", resp, err)
+					}
+				},
+				Config:      testAccUserConfig_passwordless,
+				ExpectError: regexp.MustCompile("RabbitMQ user mctest already exists, import it or set on_conflict to adopt or overwrite"),
+			},
+			{
+				// The existing user is adopted as it is
+				Config: testAccUserConfig_adopt,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserConnect("mctest", "existing"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_tagsDrift(t *testing.T) {
+	var user string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy(user),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig_tagsOrder_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserCheckTagCount(&user, 2),
+				),
+			},
+			{
+				// Reordering tags must not produce a diff
+				Config:   testAccUserConfig_tagsOrder_2,
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					rmqc := testAccProvider.Meta().(*providerMeta).Client
+					resp, err := rmqc.PutUser("mctest", rabbithole.UserSettings{
+						Password: "foobar",
+						Tags:     rabbithole.UserTags{},
+					})
+					if err != nil || resp.StatusCode >= 400 {
+						t.Fatalf("could not remove tags out-of-band: %v, %v", resp, err)
+					}
+				},
+				Config:             testAccUserConfig_tagsOrder_1,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccUserConfig_tagsOrder_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserCheckTagCount(&user, 2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_customTags(t *testing.T) {
+	var user string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy(user),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserConfig_customTags_1,
+				ExpectError: regexp.MustCompile("Unknown user tag \"foo\""),
+			},
+			{
+				Config: testAccUserConfig_customTags_2,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserCheckTagCount(&user, 2),
+				),
+			},
+		},
+	})
+}
+
 func testAccUserCheck(rn string, name *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -155,7 +383,7 @@ func testAccUserCheck(rn string, name *string) resource.TestCheckFunc {
 			return fmt.Errorf("user id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 		users, err := rmqc.ListUsers()
 		if err != nil {
 			return fmt.Errorf("Error retrieving users: %s", err)
@@ -187,9 +415,25 @@ func testAccUserConnect(username, password string) resource.TestCheckFunc {
 	}
 }
 
+func testAccUserCheckPasswordless(name *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		user, err := rmqc.GetUser(*name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving user: %s", err)
+		}
+
+		if user.PasswordHash != "" {
+			return fmt.Errorf("Expected user %s to be passwordless", *name)
+		}
+
+		return nil
+	}
+}
+
 func testAccUserCheckTagCount(name *string, tagCount int) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 		user, err := rmqc.GetUser(*name)
 		if err != nil {
 			return fmt.Errorf("Error retrieving user: %s", err)
@@ -212,7 +456,7 @@ func testAccUserCheckTagCount(name *string, tagCount int) resource.TestCheckFunc
 
 func testAccUserCheckDestroy(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 		users, err := rmqc.ListUsers()
 		if err != nil {
 			return fmt.Errorf("Error retrieving users: %s", err)
@@ -296,3 +540,74 @@ resource "rabbitmq_user" "test" {
     password = "foobarry"
     tags = ["administrator", "management"]
 }`
+
+const testAccUserConfig_passwordHash = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password_hash = "YWJjZIUHTppFMIkIuUJqA+tJ1PY/hi7ldTD7Dz4uYusT6Uen"
+    tags = ["management"]
+}`
+
+const testAccUserConfig_passwordHashDataSource = `
+data "rabbitmq_password_hash" "test" {
+    password = "foobarry"
+    salt = "efgh"
+    hashing_algorithm = "rabbit_password_hashing_sha512"
+}
+
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password_hash = data.rabbitmq_password_hash.test.password_hash
+    hashing_algorithm = data.rabbitmq_password_hash.test.hashing_algorithm
+    tags = ["management"]
+}`
+
+const testAccUserConfig_passwordless = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    tags = ["management"]
+}`
+
+const testAccUserConfig_verifyPassword = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+    verify_password = true
+    tags = ["management"]
+}`
+
+const testAccUserConfig_tagsOrder_1 = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+    tags = ["administrator", "management"]
+}`
+
+const testAccUserConfig_tagsOrder_2 = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+    tags = ["management", "administrator"]
+}`
+
+const testAccUserConfig_customTags_1 = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+    tags = ["management", "foo"]
+}`
+
+const testAccUserConfig_customTags_2 = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+    allow_custom_tags = true
+    tags = ["management", "foo"]
+}`
+
+const testAccUserConfig_adopt = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    tags = ["management"]
+    on_conflict = "adopt"
+}`
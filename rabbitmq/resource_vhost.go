@@ -12,6 +12,7 @@ import (
 func resourceVhost() *schema.Resource {
 	return &schema.Resource{
 		Create: CreateVhost,
+		Update: UpdateVhost,
 		Read:   ReadVhost,
 		Delete: DeleteVhost,
 		Importer: &schema.ResourceImporter{
@@ -24,30 +25,42 @@ func resourceVhost() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+
+			"on_conflict": onConflictSchema(),
 		},
 	}
 }
 
 func CreateVhost(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	vhost := d.Get("name").(string)
 
-	log.Printf("[DEBUG] RabbitMQ: Attempting to create vhost %s", vhost)
-
-	resp, err := rmqc.PutVhost(vhost, rabbithole.VhostSettings{})
-	log.Printf("[DEBUG] RabbitMQ: vhost creation response: %#v", resp)
+	action, err := checkConflict(d, meta.(*providerMeta), "overwrite", fmt.Sprintf("RabbitMQ vhost %s", vhost), func() error {
+		_, err := rmqc.GetVhost(vhost)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
+	if action != "adopt" {
+		log.Printf("[DEBUG] RabbitMQ: Attempting to create vhost %s", vhost)
+
+		resp, err := rmqc.PutVhost(vhost, rabbithole.VhostSettings{})
+		log.Printf("[DEBUG] RabbitMQ: vhost creation response: %#v", resp)
+		if err != nil {
+			return err
+		}
+	}
+
 	d.SetId(vhost)
 
 	return ReadVhost(d, meta)
 }
 
 func ReadVhost(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	vhost, err := rmqc.GetVhost(d.Id())
 	if err != nil {
@@ -61,8 +74,14 @@ func ReadVhost(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// UpdateVhost only handles changes of on_conflict, which has no effect once
+// the vhost is created.
+func UpdateVhost(d *schema.ResourceData, meta interface{}) error {
+	return ReadVhost(d, meta)
+}
+
 func DeleteVhost(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*providerMeta).Client
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to delete vhost %s", d.Id())
 
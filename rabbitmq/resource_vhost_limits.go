@@ -0,0 +1,56 @@
+package rabbitmq
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceVhostLimits() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateVhostLimits,
+		Update: UpdateVhostLimits,
+		Read:   ReadVhostLimits,
+		Delete: DeleteVhostLimits,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vhost": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"max_connections": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+
+			"max_queues": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+		},
+	}
+}
+
+func CreateVhostLimits(d *schema.ResourceData, meta interface{}) error {
+	return vhostLimits.create(d, meta)
+}
+
+func ReadVhostLimits(d *schema.ResourceData, meta interface{}) error {
+	return vhostLimits.read(d, meta)
+}
+
+func UpdateVhostLimits(d *schema.ResourceData, meta interface{}) error {
+	return vhostLimits.update(d, meta)
+}
+
+func DeleteVhostLimits(d *schema.ResourceData, meta interface{}) error {
+	return vhostLimits.remove(d, meta)
+}
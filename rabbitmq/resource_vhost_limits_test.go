@@ -0,0 +1,128 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVhostLimits(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccVhostLimitsCheckDestroy("test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVhostLimitsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccVhostLimitsCheck("rabbitmq_vhost_limits.test", rabbithole.VhostLimitsValues{
+						"max-connections": 10,
+						"max-queues":      20,
+					}),
+					resource.TestCheckResourceAttr("data.rabbitmq_vhost_limits.test", "max_queues", "20"),
+				),
+			},
+			{
+				Config: testAccVhostLimitsConfig_update,
+				Check: testAccVhostLimitsCheck("rabbitmq_vhost_limits.test", rabbithole.VhostLimitsValues{
+					"max-queues": 0,
+				}),
+			},
+			{
+				// Limits changed outside of Terraform must be detected
+				PreConfig: func() {
+					rmqc := testAccProvider.Meta().(*providerMeta).Client
+					if _, err := rmqc.PutVhostLimits("test", rabbithole.VhostLimitsValues{"max-queues": 5}); err != nil {
+						t.Fatalf("could not change vhost limits: %v", err)
+					}
+				},
+				Config:             testAccVhostLimitsConfig_update,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccVhostLimitsCheck(rn string, expected rabbithole.VhostLimitsValues) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("vhost limits id not set")
+		}
+
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		limits, err := rmqc.GetVhostLimits(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error retrieving vhost limits: %s", err)
+		}
+
+		values := rabbithole.VhostLimitsValues{}
+		for _, limit := range limits {
+			values = limit.Value
+		}
+
+		if len(values) != len(expected) {
+			return fmt.Errorf("Expected vhost limits %v, got %v", expected, values)
+		}
+
+		for name, value := range expected {
+			if values[name] != value {
+				return fmt.Errorf("Expected vhost limits %v, got %v", expected, values)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccVhostLimitsCheckDestroy(vhost string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
+		limits, err := rmqc.GetAllVhostLimits()
+		if err != nil {
+			return fmt.Errorf("Error retrieving vhost limits: %s", err)
+		}
+
+		for _, limit := range limits {
+			if limit.Vhost == vhost {
+				return fmt.Errorf("Vhost limits still exist for %s", vhost)
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccVhostLimitsConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_vhost_limits" "test" {
+    vhost = rabbitmq_vhost.test.name
+    max_connections = 10
+    max_queues = 20
+}
+
+data "rabbitmq_vhost_limits" "test" {
+    vhost = rabbitmq_vhost_limits.test.vhost
+}`
+
+const testAccVhostLimitsConfig_update = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_vhost_limits" "test" {
+    vhost = rabbitmq_vhost.test.name
+    max_queues = 0
+}`
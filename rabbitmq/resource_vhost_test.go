@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -39,7 +37,7 @@ func TestAccVhost(t *testing.T) {
 
 func forceDropVhost(vhost *string) func() {
 	return func() {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 		resp, err := rmqc.DeleteVhost(*vhost)
 		if err != nil {
 			fmt.Printf("unable to delete vhost: %v", err)
@@ -64,7 +62,7 @@ func testAccVhostCheck(rn string, name *string) resource.TestCheckFunc {
 			return fmt.Errorf("vhost id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 		vhosts, err := rmqc.ListVhosts()
 		if err != nil {
 			return fmt.Errorf("Error retrieving vhosts: %s", err)
@@ -83,7 +81,7 @@ func testAccVhostCheck(rn string, name *string) resource.TestCheckFunc {
 
 func testAccVhostCheckDestroy(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*providerMeta).Client
 		vhosts, err := rmqc.ListVhosts()
 		if err != nil {
 			return fmt.Errorf("Error retrieving vhosts: %s", err)
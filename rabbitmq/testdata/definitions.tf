@@ -0,0 +1,120 @@
+resource "rabbitmq_vhost" "default" {
+  name = "/"
+}
+
+resource "rabbitmq_vhost" "orders" {
+  name = "orders"
+}
+
+resource "rabbitmq_user" "orders" {
+  name              = "orders"
+  password_hash     = "kI3GCqW5JLMJa4iX1lo7X4D6XbYqlLgxIs30+P6tENUV2POR"
+  hashing_algorithm = "rabbit_password_hashing_sha256"
+  tags              = ["monitoring"]
+}
+
+resource "rabbitmq_permissions" "orders_orders" {
+  user  = rabbitmq_user.orders.name
+  vhost = rabbitmq_vhost.orders.name
+  permissions {
+    configure = ".*"
+    write     = ".*"
+    read      = ".*"
+  }
+}
+
+resource "rabbitmq_exchange" "orders_events" {
+  name  = "events"
+  vhost = rabbitmq_vhost.orders.name
+  settings {
+    type        = "topic"
+    durable     = true
+    auto_delete = false
+    arguments = {
+      alternate-exchange = "unrouted"
+    }
+  }
+}
+
+resource "rabbitmq_topic_permission" "orders_orders_events" {
+  user     = rabbitmq_user.orders.name
+  vhost    = rabbitmq_vhost.orders.name
+  exchange = rabbitmq_exchange.orders_events.name
+  write    = "^orders\\."
+  read     = ".*"
+}
+
+resource "rabbitmq_queue" "orders_orders" {
+  name  = "orders"
+  vhost = rabbitmq_vhost.orders.name
+  settings {
+    durable        = true
+    auto_delete    = false
+    arguments_json = "{\"x-delivery-limit\":5,\"x-queue-type\":\"quorum\"}"
+  }
+}
+
+resource "rabbitmq_binding" "orders_events_orders" {
+  source           = rabbitmq_exchange.orders_events.name
+  vhost            = rabbitmq_vhost.orders.name
+  destination      = rabbitmq_queue.orders_orders.name
+  destination_type = "queue"
+  routing_key      = "orders.#"
+}
+
+resource "rabbitmq_policy" "orders_ha" {
+  name  = "ha"
+  vhost = rabbitmq_vhost.orders.name
+  policy {
+    pattern  = "^orders\\."
+    priority = 1
+    apply_to = "queues"
+    definition = {
+      federation-upstream-set = "all"
+      max-length              = "10000"
+    }
+  }
+}
+
+resource "rabbitmq_operator_policy" "orders_ttl" {
+  name  = "ttl"
+  vhost = rabbitmq_vhost.orders.name
+  policy {
+    pattern  = ".*"
+    priority = 0
+    apply_to = "queues"
+    definition = {
+      message-ttl = "86400000"
+    }
+  }
+}
+
+resource "rabbitmq_shovel" "orders_legacy" {
+  name  = "legacy"
+  vhost = rabbitmq_vhost.orders.name
+  info {
+    source_uri          = "amqp://legacy"
+    source_queue        = "legacy-orders"
+    source_delete_after = "never"
+    destination_uri     = "amqp://"
+    destination_queue   = "orders"
+    ack_mode            = "on-confirm"
+  }
+}
+
+resource "rabbitmq_federation_upstream" "orders_upstream" {
+  name  = "upstream"
+  vhost = rabbitmq_vhost.orders.name
+  definition {
+    uri             = "amqp://upstream"
+    prefetch_count  = 1000
+    reconnect_delay = 5
+    max_hops        = 1
+    ack_mode        = "on-confirm"
+  }
+}
+
+resource "rabbitmq_vhost_limits" "orders" {
+  vhost           = rabbitmq_vhost.orders.name
+  max_connections = 10
+}
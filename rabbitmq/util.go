@@ -1,14 +1,39 @@
 package rabbitmq
 
 import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 )
 
+// importStatePassthroughWithDefaults works like schema.ImportStatePassthroughContext
+// but also sets the given defaults, which would otherwise be missing from the
+// imported state and show up as a diff on the next plan.
+func importStatePassthroughWithDefaults(defaults map[string]interface{}) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		for key, value := range defaults {
+			if err := d.Set(key, value); err != nil {
+				return nil, err
+			}
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
 func checkDeleted(d *schema.ResourceData, err error) error {
 	var errorResponse rabbithole.ErrorResponse
 	if errors.As(err, &errorResponse) {
@@ -51,3 +76,198 @@ func parseId(resourceId string) (name, vhost string, err error) {
 	vhost = parts[1]
 	return
 }
+
+// RabbitMQ stores user passwords as base64(salt + hash(salt + password)) where
+// the salt is 4 bytes long. See https://www.rabbitmq.com/passwords.html#computing-password-hash
+const passwordHashSaltLength = 4
+
+// compute the salted password hash the way the RabbitMQ internal backend does
+func hashUserPassword(password string, salt []byte, algorithm rabbithole.HashingAlgorithm) (string, error) {
+	if len(salt) != passwordHashSaltLength {
+		return "", fmt.Errorf("Password salt must be exactly %d bytes long, got %d", passwordHashSaltLength, len(salt))
+	}
+
+	salted := append(append([]byte{}, salt...), []byte(password)...)
+
+	var digest []byte
+	switch algorithm {
+	case rabbithole.HashingAlgorithmSHA256, "":
+		sum := sha256.Sum256(salted)
+		digest = sum[:]
+	case rabbithole.HashingAlgorithmSHA512:
+		sum := sha512.Sum512(salted)
+		digest = sum[:]
+	case rabbithole.HashingAlgorithmMD5:
+		sum := md5.Sum(salted)
+		digest = sum[:]
+	default:
+		return "", fmt.Errorf("Unsupported hashing algorithm: %s", algorithm)
+	}
+
+	return base64.StdEncoding.EncodeToString(append(append([]byte{}, salt...), digest...)), nil
+}
+
+// extract the salt from a base64 encoded password hash as returned by the API
+func passwordHashSalt(hash string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(hash)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode password hash: %w", err)
+	}
+
+	if len(decoded) <= passwordHashSaltLength {
+		return nil, fmt.Errorf("Password hash is too short")
+	}
+
+	return decoded[:passwordHashSaltLength], nil
+}
+
+// formatValue returns the string form of an argument value. Numbers decoded
+// from JSON are float64, they are formatted without exponent so that they can
+// be compared with the values of maps of strings.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// argumentsMatch checks that every filter is set to the same value in the
+// arguments of an object. Values are compared in their string form since
+// filters come from a map of strings.
+func argumentsMatch(arguments map[string]interface{}, filters map[string]interface{}) bool {
+	for key, expected := range filters {
+		actual, ok := arguments[key]
+		if !ok || formatValue(actual) != formatValue(expected) {
+			return false
+		}
+	}
+	return true
+}
+
+// argumentConflicts describes the arguments of an existing object which differ
+// from the configured ones. Unlike argumentsMatch, values are compared with
+// their type, since RabbitMQ treats the string "100" and the number 100 as
+// different arguments.
+func argumentConflicts(existing map[string]interface{}, configured map[string]interface{}) []string {
+	keys := make(map[string]bool)
+	for key := range existing {
+		keys[key] = true
+	}
+	for key := range configured {
+		keys[key] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	var conflicts []string
+	for _, key := range sorted {
+		actual, exists := existing[key]
+		expected, ok := configured[key]
+		switch {
+		case !ok:
+			conflicts = append(conflicts, fmt.Sprintf("argument %s is set to %s", key, quoteValue(actual)))
+		case !exists:
+			conflicts = append(conflicts, fmt.Sprintf("argument %s is not set", key))
+		case !argumentValuesEqual(actual, expected):
+			conflicts = append(conflicts, fmt.Sprintf("argument %s is %s instead of %s", key, quoteValue(actual), quoteValue(expected)))
+		}
+	}
+
+	return conflicts
+}
+
+// argumentValuesEqual compares two argument values by their JSON encoding,
+// which keeps their type but doesn't tell the numeric Go types apart.
+func argumentValuesEqual(a interface{}, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+
+	return bytes.Equal(encodedA, encodedB)
+}
+
+// quoteValue formats an argument value for messages, quoting strings so that
+// they can be told apart from numbers.
+func quoteValue(value interface{}) string {
+	if v, ok := value.(string); ok {
+		return strconv.Quote(v)
+	}
+
+	return formatValue(value)
+}
+
+// get the optional boolean filter of a data source, unset means no filter
+func optionalBoolFilter(d *schema.ResourceData, key string) *bool {
+	if d.GetRawConfig().GetAttr(key).IsNull() {
+		return nil
+	}
+	value := d.Get(key).(bool)
+	return &value
+}
+
+// policyAppliesTo checks if a policy "apply-to" value covers an object of the
+// given kind, either "queue" or "exchange". The queue type is only used for
+// the queue type specific values introduced in RabbitMQ 3.12.
+func policyAppliesTo(applyTo string, kind string, queueType string) bool {
+	switch applyTo {
+	case "all":
+		return true
+	case "queues":
+		return kind == "queue"
+	case "exchanges":
+		return kind == "exchange"
+	case "classic_queues":
+		return kind == "queue" && (queueType == "classic" || queueType == "")
+	case "quorum_queues":
+		return kind == "queue" && queueType == "quorum"
+	case "streams":
+		return kind == "queue" && queueType == "stream"
+	}
+	return false
+}
+
+// policyMatches checks if a policy applies to the object with the given name.
+func policyMatches(pattern string, applyTo string, kind string, name string, queueType string) (bool, error) {
+	if !policyAppliesTo(applyTo, kind, queueType) {
+		return false, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("Invalid policy pattern %q: %w", pattern, err)
+	}
+
+	return re.MatchString(name), nil
+}
+
+// mergePolicyDefinitions computes the definition RabbitMQ applies when both a
+// policy and an operator policy match an object: numeric values conflicting
+// between the two use the lowest one, otherwise the operator policy wins.
+func mergePolicyDefinitions(policy map[string]interface{}, operatorPolicy map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for key, value := range policy {
+		merged[key] = value
+	}
+
+	for key, value := range operatorPolicy {
+		if current, ok := merged[key]; ok {
+			currentNumber, currentIsNumber := current.(float64)
+			number, isNumber := value.(float64)
+			if currentIsNumber && isNumber && currentNumber < number {
+				continue
+			}
+		}
+		merged[key] = value
+	}
+
+	return merged
+}
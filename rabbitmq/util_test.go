@@ -1,6 +1,11 @@
 package rabbitmq
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+)
 
 func TestParseId(t *testing.T) {
 	var badInputs = []string{
@@ -34,3 +39,225 @@ func TestParseId(t *testing.T) {
 		}
 	}
 }
+
+func TestHashUserPassword(t *testing.T) {
+	var goodInputs = []struct {
+		password  string
+		salt      []byte
+		algorithm rabbithole.HashingAlgorithm
+		hash      string
+	}{
+		// Example from https://www.rabbitmq.com/passwords.html#computing-password-hash
+		{"test12", []byte{0x90, 0x8D, 0xC6, 0x0A}, rabbithole.HashingAlgorithmSHA256, "kI3GCqW5JLMJa4iX1lo7X4D6XbYqlLgxIs30+P6tENUV2POR"},
+		{"test12", []byte{0x90, 0x8D, 0xC6, 0x0A}, "", "kI3GCqW5JLMJa4iX1lo7X4D6XbYqlLgxIs30+P6tENUV2POR"},
+		{"foobar", []byte("abcd"), rabbithole.HashingAlgorithmSHA512, "YWJjZOZa+FYGzs/j3TBYPUlxeycEh/zgCfY0T/d/b5+vkXqfYA4DNey0e08SMzow6xAhZPoAIwkZkP53lD3rBwg/gcc="},
+		{"foobar", []byte("abcd"), rabbithole.HashingAlgorithmMD5, "YWJjZDZHPvL4uRrhIUEa5vDKzjQ="},
+	}
+
+	for _, test := range goodInputs {
+		hash, err := hashUserPassword(test.password, test.salt, test.algorithm)
+		if err != nil || hash != test.hash {
+			t.Errorf("hashUserPassword failed for %s with %s: got %s, %v", test.password, test.algorithm, hash, err)
+		}
+	}
+
+	if _, err := hashUserPassword("foobar", []byte("abc"), rabbithole.HashingAlgorithmSHA256); err == nil {
+		t.Errorf("hashUserPassword should fail with a short salt")
+	}
+
+	if _, err := hashUserPassword("foobar", []byte("abcd"), "rabbit_password_hashing_foo"); err == nil {
+		t.Errorf("hashUserPassword should fail with an unknown algorithm")
+	}
+}
+
+func TestPasswordHashSalt(t *testing.T) {
+	salt, err := passwordHashSalt("kI3GCqW5JLMJa4iX1lo7X4D6XbYqlLgxIs30+P6tENUV2POR")
+	if err != nil || string(salt) != string([]byte{0x90, 0x8D, 0xC6, 0x0A}) {
+		t.Errorf("passwordHashSalt failed: got %v, %v", salt, err)
+	}
+
+	var badInputs = []string{
+		"",
+		"not base64!",
+		"YWJjZA==",
+	}
+
+	for _, input := range badInputs {
+		if _, err := passwordHashSalt(input); err == nil {
+			t.Errorf("passwordHashSalt should fail for: %s.", input)
+		}
+	}
+}
+
+func TestParseTopicPermissionId(t *testing.T) {
+	var badInputs = []string{
+		"",
+		"foo@test",
+		"foo/test",
+		"foo/bar/baz/test",
+	}
+
+	for _, input := range badInputs {
+		_, _, _, err := parseTopicPermissionId(input)
+		if err == nil {
+			t.Errorf("parseTopicPermissionId failed for: %s.", input)
+		}
+	}
+
+	var goodInputs = []struct {
+		user     string
+		vhost    string
+		exchange string
+	}{
+		{"foo", "test", "amq.topic"},
+		{"foo", "/", "amq.topic"},
+		{"foo@bar.com", "a/b%2F", "x/y"},
+	}
+
+	for _, test := range goodInputs {
+		id := topicPermissionId(test.user, test.vhost, test.exchange)
+		user, vhost, exchange, err := parseTopicPermissionId(id)
+		if err != nil || user != test.user || vhost != test.vhost || exchange != test.exchange {
+			t.Errorf("parseTopicPermissionId failed for: %s.", id)
+		}
+	}
+}
+
+func TestArgumentsMatch(t *testing.T) {
+	arguments := map[string]interface{}{
+		"x-queue-type":       "quorum",
+		"x-max-length":       float64(100),
+		"x-message-ttl":      float64(86400000),
+		"x-max-length-bytes": float64(1000000),
+	}
+
+	var tests = []struct {
+		filters map[string]interface{}
+		match   bool
+	}{
+		{map[string]interface{}{}, true},
+		{map[string]interface{}{"x-queue-type": "quorum"}, true},
+		{map[string]interface{}{"x-queue-type": "quorum", "x-max-length": "100"}, true},
+		{map[string]interface{}{"x-queue-type": "classic"}, false},
+		{map[string]interface{}{"x-message-ttl": "100"}, false},
+		{map[string]interface{}{"x-message-ttl": "86400000"}, true},
+		{map[string]interface{}{"x-max-length-bytes": "1000000"}, true},
+		{map[string]interface{}{"x-max-length-bytes": "1e+06"}, false},
+	}
+
+	for _, test := range tests {
+		if argumentsMatch(arguments, test.filters) != test.match {
+			t.Errorf("argumentsMatch failed for: %v.", test.filters)
+		}
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	var tests = []struct {
+		value    interface{}
+		expected string
+	}{
+		{"quorum", "quorum"},
+		{float64(100), "100"},
+		{float64(86400000), "86400000"},
+		{float64(1000000), "1000000"},
+		{float64(1.5), "1.5"},
+		{true, "true"},
+		{5, "5"},
+	}
+
+	for _, test := range tests {
+		if actual := formatValue(test.value); actual != test.expected {
+			t.Errorf("formatValue(%#v) = %q, expected %q", test.value, actual, test.expected)
+		}
+	}
+}
+
+func TestArgumentConflicts(t *testing.T) {
+	existing := map[string]interface{}{"x-max-length": 10.0, "x-expires": 60000.0, "x-overflow": "reject-publish"}
+	configured := map[string]interface{}{"x-max-length": "10", "x-expires": 30000, "x-message-ttl": 1000}
+
+	expected := []string{
+		"argument x-expires is 60000 instead of 30000",
+		"argument x-max-length is 10 instead of \"10\"",
+		"argument x-message-ttl is not set",
+		"argument x-overflow is set to \"reject-publish\"",
+	}
+	if conflicts := argumentConflicts(existing, configured); !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("Expected conflicts %v, got %v", expected, conflicts)
+	}
+
+	if conflicts := argumentConflicts(existing, existing); len(conflicts) > 0 {
+		t.Errorf("Expected no conflicts, got %v", conflicts)
+	}
+
+	existing = map[string]interface{}{"x-message-ttl": 86400000.0, "x-single-active-consumer": true}
+	configured = map[string]interface{}{"x-message-ttl": 86400000, "x-single-active-consumer": true}
+	if conflicts := argumentConflicts(existing, configured); len(conflicts) > 0 {
+		t.Errorf("Expected no conflicts, got %v", conflicts)
+	}
+
+	configured = map[string]interface{}{"x-message-ttl": "86400000", "x-single-active-consumer": "true"}
+	expected = []string{
+		"argument x-message-ttl is 86400000 instead of \"86400000\"",
+		"argument x-single-active-consumer is true instead of \"true\"",
+	}
+	if conflicts := argumentConflicts(existing, configured); !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("Expected conflicts %v, got %v", expected, conflicts)
+	}
+}
+
+func TestPolicyMatches(t *testing.T) {
+	var tests = []struct {
+		pattern   string
+		applyTo   string
+		kind      string
+		name      string
+		queueType string
+		match     bool
+	}{
+		{".*", "all", "queue", "foo", "classic", true},
+		{".*", "all", "exchange", "foo", "", true},
+		{"^foo$", "queues", "queue", "foo", "quorum", true},
+		{"^foo$", "queues", "queue", "foobar", "quorum", false},
+		{"^foo", "exchanges", "queue", "foo", "classic", false},
+		{"^foo", "quorum_queues", "queue", "foo", "classic", false},
+		{"^foo", "quorum_queues", "queue", "foo", "quorum", true},
+		{"^foo", "classic_queues", "queue", "foo", "", true},
+		{"^foo", "streams", "queue", "foo", "stream", true},
+	}
+
+	for _, test := range tests {
+		match, err := policyMatches(test.pattern, test.applyTo, test.kind, test.name, test.queueType)
+		if err != nil || match != test.match {
+			t.Errorf("policyMatches failed for: %v.", test)
+		}
+	}
+
+	if _, err := policyMatches("(", "all", "queue", "foo", ""); err == nil {
+		t.Errorf("policyMatches should fail with an invalid pattern")
+	}
+}
+
+func TestMergePolicyDefinitions(t *testing.T) {
+	merged := mergePolicyDefinitions(
+		map[string]interface{}{"max-length": float64(100), "message-ttl": float64(1000), "ha-mode": "all"},
+		map[string]interface{}{"max-length": float64(1000), "message-ttl": float64(10), "overflow": "reject-publish"},
+	)
+
+	expected := map[string]interface{}{
+		"max-length":  float64(100),
+		"message-ttl": float64(10),
+		"ha-mode":     "all",
+		"overflow":    "reject-publish",
+	}
+
+	if len(merged) != len(expected) {
+		t.Fatalf("mergePolicyDefinitions returned %v", merged)
+	}
+	for key, value := range expected {
+		if merged[key] != value {
+			t.Errorf("mergePolicyDefinitions returned %v for %s, expected %v", merged[key], key, value)
+		}
+	}
+}